@@ -0,0 +1,51 @@
+package xlsx2struct
+
+import (
+	"strconv"
+
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+// A CellRef locates a cell in a sheet the way Excel users see it.
+type CellRef struct {
+	Sheet   string // sheet name
+	Row     int    // row number (one based)
+	Col     string // column letters, e.g. "D"
+	Heading string // heading of the column, if known
+}
+
+func newCellRef(c *xlsx3.Cell, heading string) CellRef {
+	r := CellRef{Heading: heading}
+	if c == nil || c.Row == nil {
+		return r
+	}
+
+	x, y := c.GetCoordinates()
+	r.Row = y + 1
+	r.Col = xlsx3.ColIndexToLetters(x)
+	if c.Row.Sheet != nil {
+		r.Sheet = c.Row.Sheet.Name
+	}
+
+	return r
+}
+
+// Address returns the cell address in A1 notation, e.g. "D17".
+func (r CellRef) Address() string {
+	if r.Col == "" {
+		return ""
+	}
+	return r.Col + strconv.Itoa(r.Row)
+}
+
+// String describes the cell, e.g. "sheet 'Orders', cell D17 (column 'Units')".
+func (r CellRef) String() string {
+	s := "cell " + r.Address()
+	if r.Sheet != "" {
+		s = "sheet '" + r.Sheet + "', " + s
+	}
+	if r.Heading != "" {
+		s += " (column '" + r.Heading + "')"
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package xlsx2struct
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+func TestCellRef(t *testing.T) {
+	sheet := sheetOf(t, []string{"Order Date", "Region", "Rep", "Units"})
+	c, err := sheet.Cell(16, 3)
+	require.NoError(t, err)
+
+	r := newCellRef(c, "Units")
+	require.Equal(t, CellRef{Sheet: "Sheet1", Row: 17, Col: "D", Heading: "Units"}, r)
+	require.Equal(t, "D17", r.Address())
+	require.Equal(t, "sheet 'Sheet1', cell D17 (column 'Units')", r.String())
+
+	// cell without row
+	r = newCellRef(nil, "Units")
+	require.Equal(t, "", r.Address())
+}
+
+func TestErrorLocations(t *testing.T) {
+	type Struct1 struct {
+		Units int       `column:"heading=Units|Qty"`
+		Date  time.Time `column:"heading=Date"`
+		Code  string    `column:"heading=Code,conv=code"`
+	}
+
+	sheet := sheetOf(t,
+		[]string{"qty", "Date", "Code"},
+		[]string{"ten", "soon", "x"},
+	)
+
+	opt := DefaultSheetOptions()
+	opt.HeadingMatch = MatchFoldCase
+	opt.Lenient = true
+	opt.Converters = map[string]Converter{
+		"code": func(*xlsx3.Cell, reflect.Type) (any, error) { return 1, nil },
+	}
+
+	err := Unmarshal(sheet, &[]Struct1{}, opt)
+
+	// heading as found in the sheet
+	var ferr *UnmarshalFieldError
+	require.ErrorAs(t, err, &ferr)
+	require.Equal(t, "qty", ferr.Heading)
+
+	var uerr *UnsupportedValueError
+	require.ErrorAs(t, err, &uerr)
+	require.Equal(t, CellRef{Sheet: "Sheet1", Row: 2, Col: "B", Heading: "Date"}, uerr.CellRef)
+	require.Equal(t, `xlsx2struct: sheet 'Sheet1', cell B2 (column 'Date'): unsupported value "soon"`, uerr.Error())
+
+	var verr *InvalidFieldValueError
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, "C2", verr.Address())
+	require.Equal(t, "xlsx2struct: sheet 'Sheet1', cell C2 (column 'Code'): invalid value '1' (type: int) for field 'Code' (type: string, column: 'Code')", verr.Error())
+}
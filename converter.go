@@ -0,0 +1,95 @@
+package xlsx2struct
+
+import (
+	"reflect"
+	"sync"
+
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+// A Converter converts a cell to a value of type t. Converters are selected
+// per field with the conv option, e.g. `column:"heading=Price,conv=money"`.
+//
+// The cell passed to a converter has trim and default options applied to its value.
+// For a pointer field, t is the type pointed to. The converted value must be assignable
+// to t, e.g. any value for a field of type any.
+//
+// Converters only read cells: [Marshal] writes the field value unconverted, so a sheet
+// written with conv fields cannot be read back with the same converters. A field type
+// implementing [CellMarshaler] or [encoding.TextMarshaler] and an unmarshaler interface
+// round trips instead.
+type Converter func(cell *xlsx3.Cell, t reflect.Type) (any, error)
+
+var (
+	convertersMu sync.RWMutex
+	converters   = map[string]Converter{}
+)
+
+// RegisterConverter makes a converter available to all sheets by the provided name.
+// Registering a converter with the name of an existing converter replaces it, and
+// registering a nil converter removes it.
+func RegisterConverter(name string, fn Converter) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+
+	if fn == nil {
+		delete(converters, name)
+		return
+	}
+	converters[name] = fn
+}
+
+// lookupConverter returns the named converter from opt, or from the registered converters.
+func lookupConverter(name string, opt *SheetOptions) Converter {
+	if opt != nil {
+		if fn, ok := opt.Converters[name]; ok {
+			return fn
+		}
+	}
+
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+
+	return converters[name]
+}
+
+// resolveConverters assigns converters to fields with the conv option.
+func resolveConverters(fields map[*Field]*Column, opt *SheetOptions) error {
+	for f := range fields {
+		if f.tag.converter == "" {
+			continue
+		}
+
+		fn := lookupConverter(f.tag.converter, opt)
+		if fn == nil {
+			return &UnknownConverterError{Field: f, Name: f.tag.converter}
+		}
+		f.converter = fn
+		if f.group != nil {
+			f.group.elem.converter = fn
+		}
+	}
+
+	return nil
+}
+
+// convert converts v, the value of cell, using the field's converter.
+func convert(field *Field, t reflect.Type, cell *xlsx3.Cell, v string) (any, error) {
+	c := cell
+	if v != cell.Value {
+		cc := *cell
+		cc.Value = v
+		c = &cc
+	}
+
+	a, err := field.converter(c, t)
+	if err != nil {
+		return nil, err
+	}
+
+	if a == nil || !reflect.TypeOf(a).AssignableTo(t) {
+		return nil, &InvalidFieldValueError{Field: field, Value: a}
+	}
+
+	return a, nil
+}
@@ -0,0 +1,76 @@
+package xlsx2struct
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+func centsConverter(cell *xlsx3.Cell, t reflect.Type) (any, error) {
+	f, err := strconv.ParseFloat(strings.TrimPrefix(cell.Value, "$"), 64)
+	if err != nil {
+		return nil, err
+	}
+	return reflect.ValueOf(int64(math.Round(f * 100))).Convert(t).Interface(), nil
+}
+
+type Item struct {
+	Name  string `column:"heading=Name"`
+	Price int64  `column:"heading=Price,conv=cents"`
+	Units int64  `column:"heading=Units"`
+}
+
+func TestConverters(t *testing.T) {
+	sheet := sheetOf(t,
+		[]string{"Name", "Price", "Units"},
+		[]string{"Pencil", "$1.99", "10"},
+	)
+
+	// unknown converter
+	a := []Item{}
+	err := Unmarshal(sheet, &a, nil)
+	require.EqualError(t, err, `xlsx2struct: unknown converter "cents" for field 'Price' (type: int64, column: 'Price')`)
+
+	// converter in options
+	opt := DefaultSheetOptions()
+	opt.Converters = map[string]Converter{"cents": centsConverter}
+	err = Unmarshal(sheet, &a, opt)
+	require.NoError(t, err)
+	require.Equal(t, []Item{{Name: "Pencil", Price: 199, Units: 10}}, a)
+
+	// registered converter
+	RegisterConverter("cents", centsConverter)
+	defer RegisterConverter("cents", nil)
+
+	err = Unmarshal(sheet, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(199), a[0].Price)
+
+	// options take precedence
+	opt.Converters["cents"] = func(*xlsx3.Cell, reflect.Type) (any, error) { return "199", nil }
+	err = Unmarshal(sheet, &a, opt)
+	var verr *InvalidFieldValueError
+	require.ErrorAs(t, err, &verr)
+
+	// converted value assignable to the field
+	type Struct1 struct {
+		Price any `column:"heading=Price,conv=cents"`
+	}
+	b := []Struct1{}
+	opt.Converters["cents"] = func(*xlsx3.Cell, reflect.Type) (any, error) { return int64(199), nil }
+	err = Unmarshal(sheet, &b, opt)
+	require.NoError(t, err)
+	require.Equal(t, []Struct1{{int64(199)}}, b)
+
+	// marshal writes the value unconverted
+	out := newSheet(t)
+	err = Marshal(out, []Item{{Name: "Pencil", Price: 199, Units: 10}}, nil)
+	require.NoError(t, err)
+	c, _ := out.Cell(1, 1)
+	require.Equal(t, "199", c.Value)
+}
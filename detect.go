@@ -0,0 +1,78 @@
+package xlsx2struct
+
+import (
+	"reflect"
+
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+// A Detection reports where the heading row of a sheet was found.
+// See SheetOptions.DetectRows.
+type Detection struct {
+	Row     int      // row index (zero based) of the headings
+	Col     int      // column index (zero based) of the first heading
+	DataRow int      // row index (zero based) of the first row of data
+	Matched []string // field headings found in the heading row
+}
+
+// detectHeadings scans the first rows and columns of the sheet for the block of
+// headings matching the most fields of struct type t. Ties go to the first block found.
+func detectHeadings(t reflect.Type, sheet *xlsx3.Sheet, opt *SheetOptions) (*Detection, error) {
+	fields, err := extractFields(t)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := min(opt.DetectRows, sheet.MaxRow)
+	cols := sheet.MaxCol
+	if opt.DetectCols > 0 {
+		cols = opt.DetectCols
+	}
+
+	var best *Detection
+
+	for r := 0; r < rows; r++ {
+		empty := true // previous cell is empty, i.e., a block of headings may start
+
+		for c := 0; c < cols; c++ {
+			cell, err := sheet.Cell(r, c)
+			if err != nil {
+				return nil, err
+			}
+
+			start := empty && cell.Value != ""
+			empty = cell.Value == ""
+			if !start {
+				continue
+			}
+
+			hs, err := headingColumns(sheet, r, c, opt)
+			if err != nil {
+				return nil, err
+			}
+
+			matched := matchedHeadings(fields, mapFields(fields, hs, opt.HeadingMatch))
+			if len(matched) > 0 && (best == nil || len(matched) > len(best.Matched)) {
+				best = &Detection{Row: r, Col: c, DataRow: r + opt.DataRow - opt.Row, Matched: matched}
+			}
+		}
+	}
+
+	if best == nil {
+		return nil, &HeadingsNotFoundError{Sheet: sheet.Name, Rows: rows}
+	}
+
+	return best, nil
+}
+
+// matchedHeadings returns the headings of the fields mapped to a column by heading.
+func matchedHeadings(fields []*Field, m map[*Field]*Column) []string {
+	hs := []string{}
+	for _, f := range fields {
+		if _, fixed := f.fixedIndex(); fixed || m[f] == nil {
+			continue
+		}
+		hs = append(hs, f.Heading())
+	}
+	return hs
+}
@@ -0,0 +1,56 @@
+package xlsx2struct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectHeadings(t *testing.T) {
+	type Struct1 struct {
+		Region string `column:"heading=Region"`
+		Units  int    `column:"heading=Units"`
+		Cost   int    `column:"heading=Cost"`
+	}
+
+	sheet := sheetOf(t,
+		[]string{"ACME Corp. Sales Report"},
+		[]string{},
+		[]string{"", "Region", "Notes"},
+		[]string{"", "", "Region", "Units", "Cost"},
+		[]string{"", "", "East", "10", "5"},
+		[]string{"", "", "West", "20", "6"},
+	)
+
+	var found Detection
+	opt := DefaultSheetOptions()
+	opt.DetectRows = 10
+	opt.OnDetect = func(d Detection) { found = d }
+
+	a := []Struct1{}
+	err := Unmarshal(sheet, &a, opt)
+	require.NoError(t, err)
+	require.Equal(t, []Struct1{{"East", 10, 5}, {"West", 20, 6}}, a)
+	require.Equal(t, Detection{Row: 3, Col: 2, DataRow: 4, Matched: []string{"Region", "Units", "Cost"}}, found)
+
+	// options are not modified
+	require.Equal(t, 0, opt.Row)
+
+	// scan limits find a row with only some of the columns
+	var merr *MissingColumnsError
+	opt.DetectRows = 3
+	err = Unmarshal(sheet, &a, opt)
+	require.ErrorAs(t, err, &merr)
+	require.Equal(t, 2, found.Row)
+	require.Equal(t, 1, found.Col)
+
+	opt.DetectRows = 10
+	opt.DetectCols = 2
+	err = Unmarshal(sheet, &a, opt)
+	require.ErrorAs(t, err, &merr)
+	require.Equal(t, 2, found.Row)
+
+	opt.DetectRows = 2
+	err = Unmarshal(sheet, &a, opt)
+	require.EqualError(t, err, "xlsx2struct: no headings found in the first 2 row(s) of sheet 'Sheet1'")
+}
@@ -1,9 +1,11 @@
 package xlsx2struct
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 
 	xlsx3 "github.com/tealeg/xlsx/v3"
 )
@@ -22,13 +24,162 @@ func (e *InvalidUnmarshalError) Error() string {
 	return "xlsx2struct: invalid unmarshal(nil " + e.Type.String() + ")"
 }
 
+type InvalidMarshalError struct {
+	Type reflect.Type
+}
+
+func (e *InvalidMarshalError) Error() string {
+	if e.Type == nil {
+		return "xlsx2struct: invalid marshal(nil)"
+	}
+	return "xlsx2struct: invalid marshal(non-slice of struct " + e.Type.String() + ")"
+}
+
+// InvalidUnpivotError describes a struct type passed to [UnmarshalLong] without a field
+// with the value option.
+type InvalidUnpivotError struct {
+	Type reflect.Type
+}
+
+func (e *InvalidUnpivotError) Error() string {
+	return "xlsx2struct: invalid unpivot(no value field in " + e.Type.String() + ")"
+}
+
+// UnmarshalFieldError describes a cell that cannot be unmarshalled into a field.
+// Err holds the underlying parse error, if any.
 type UnmarshalFieldError struct {
+	CellRef
 	Field *Field
 	Cell  *xlsx3.Cell
+	Value string
+	Err   error
 }
 
 func (e *UnmarshalFieldError) Error() string {
-	return "xlsx2struct: cannot unmarshal cell " + describeCell(e.Cell) + " into field " + e.Field.Describe()
+	if e.Cell == nil {
+		return "xlsx2struct: cannot unmarshal cell " + describeCell(e.Cell) + " into field " + e.Field.Describe()
+	}
+	s := "xlsx2struct: " + e.CellRef.String() + ": cannot unmarshal '" + e.Value + "' into field " + e.Field.Describe()
+	if e.Err != nil {
+		s += ": " + describeError(e.Err)
+	}
+	return s
+}
+
+func (e *UnmarshalFieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError describes a cell value that violates a validation option of a field.
+type ValidationError struct {
+	CellRef
+	Field *Field
+	Cell  *xlsx3.Cell
+	Value string
+	Rule  string // violated option, e.g. "min=1"
+}
+
+func (e *ValidationError) Error() string {
+	return "xlsx2struct: " + e.CellRef.String() + ": value '" + e.Value + "' of field " + e.Field.Describe() + " violates rule " + e.Rule
+}
+
+// InvalidTagError describes an invalid option in the column tag of a field.
+type InvalidTagError struct {
+	Field  *Field
+	Option string
+	Err    error
+}
+
+func (e *InvalidTagError) Error() string {
+	s := "xlsx2struct: invalid option " + strconv.Quote(e.Option) + " for field " + e.Field.Describe()
+	if e.Err != nil {
+		s += ": " + e.Err.Error()
+	}
+	return s
+}
+
+func (e *InvalidTagError) Unwrap() error {
+	return e.Err
+}
+
+// ElementError describes an element of a delimited cell value that cannot be
+// unmarshalled into a slice element.
+type ElementError struct {
+	Index int // element index (zero based)
+	Value string
+	Err   error
+}
+
+func (e *ElementError) Error() string {
+	return "xlsx2struct: element " + strconv.Itoa(e.Index) + " '" + e.Value + "': " + describeError(e.Err)
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// RowError records an error that occurred while reading a row of a sheet.
+type RowError struct {
+	Sheet string // sheet name
+	Row   int    // row number (one based)
+	Err   error
+}
+
+func (e *RowError) Error() string {
+	s := "xlsx2struct: "
+	if e.Sheet != "" {
+		s += "sheet '" + e.Sheet + "', "
+	}
+	return s + "row " + strconv.Itoa(e.Row) + ": " + describeError(e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+type MarshalFieldError struct {
+	Field *Field
+	Cell  *xlsx3.Cell
+}
+
+func (e *MarshalFieldError) Error() string {
+	return "xlsx2struct: cannot marshal field " + e.Field.Describe() + " into cell " + describeCell(e.Cell)
+}
+
+// UnmarshalErrors collects the errors found while reading a sheet in lenient mode.
+type UnmarshalErrors struct {
+	Errors []error
+}
+
+func (e *UnmarshalErrors) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "xlsx2struct: %d error(s) unmarshalling sheet", len(e.Errors))
+	for _, err := range e.Errors {
+		b.WriteString("\n\t")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+func (e *UnmarshalErrors) Unwrap() []error {
+	return e.Errors
+}
+
+// exceeds reports whether the number of errors has reached max. Zero max means no limit.
+func (e *UnmarshalErrors) exceeds(max int) bool {
+	return max > 0 && len(e.Errors) >= max
+}
+
+// add appends err, flattening errors joined together by unmarshalFields, until the
+// number of errors reaches max.
+func (e *UnmarshalErrors) add(err error, max int) {
+	if u, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range u.Unwrap() {
+			e.add(err, max)
+		}
+	} else if !e.exceeds(max) {
+		e.Errors = append(e.Errors, err)
+	}
 }
 
 type UnsupportedFieldError struct {
@@ -49,11 +200,88 @@ func (e *UnsupportedFieldError) Error() string {
 }
 
 type UnsupportedValueError struct {
+	CellRef
 	Value string
 }
 
 func (e *UnsupportedValueError) Error() string {
-	return "xlsx2struct: unsupported value " + strconv.Quote(e.Value)
+	return located(e.CellRef, e.message())
+}
+
+func (e *UnsupportedValueError) message() string {
+	return "unsupported value " + strconv.Quote(e.Value)
+}
+
+// MissingColumnsError lists the headings of columns missing from a sheet.
+type MissingColumnsError struct {
+	Sheet    string
+	Headings []string
+}
+
+func (e *MissingColumnsError) Error() string {
+	return "xlsx2struct: sheet '" + e.Sheet + "' is missing column(s) " + quoteHeadings(e.Headings)
+}
+
+// ExtraColumnsError lists the headings of sheet columns not mapped to any field.
+type ExtraColumnsError struct {
+	Sheet    string
+	Headings []string
+}
+
+func (e *ExtraColumnsError) Error() string {
+	return "xlsx2struct: sheet '" + e.Sheet + "' has unexpected column(s) " + quoteHeadings(e.Headings)
+}
+
+// MissingSheetsError lists the sheets missing from a workbook.
+type MissingSheetsError struct {
+	Sheets []string
+}
+
+func (e *MissingSheetsError) Error() string {
+	return "xlsx2struct: workbook is missing sheet(s) " + quoteHeadings(e.Sheets)
+}
+
+// InvalidSheetFieldError describes a field that cannot be read from a sheet, because it
+// is not an exported slice or its sheet tag has an invalid option.
+type InvalidSheetFieldError struct {
+	Field  string
+	Type   reflect.Type
+	Option string // invalid option, empty when the field type is invalid
+	Err    error
+}
+
+func (e *InvalidSheetFieldError) Error() string {
+	if e.Option == "" {
+		return "xlsx2struct: invalid sheet field '" + e.Field + "' (type: " + e.Type.String() + ")"
+	}
+	s := "xlsx2struct: invalid sheet option " + strconv.Quote(e.Option) + " for field '" + e.Field + "'"
+	if e.Err != nil {
+		s += ": " + e.Err.Error()
+	}
+	return s
+}
+
+func (e *InvalidSheetFieldError) Unwrap() error {
+	return e.Err
+}
+
+// HeadingsNotFoundError is returned when no heading row is detected in a sheet.
+type HeadingsNotFoundError struct {
+	Sheet string
+	Rows  int // number of rows scanned
+}
+
+func (e *HeadingsNotFoundError) Error() string {
+	return "xlsx2struct: no headings found in the first " + strconv.Itoa(e.Rows) + " row(s) of sheet '" + e.Sheet + "'"
+}
+
+type UnknownConverterError struct {
+	Field *Field
+	Name  string
+}
+
+func (e *UnknownConverterError) Error() string {
+	return "xlsx2struct: unknown converter " + strconv.Quote(e.Name) + " for field " + e.Field.Describe()
 }
 
 type InvalidFieldError struct {
@@ -65,12 +293,46 @@ func (e *InvalidFieldError) Error() string {
 }
 
 type InvalidFieldValueError struct {
+	CellRef
 	Field *Field
 	Value any
 }
 
 func (e *InvalidFieldValueError) Error() string {
-	return "xlsx2struct: invalid value " + describe(e.Value) + " for field " + e.Field.Describe()
+	return located(e.CellRef, e.message())
+}
+
+func (e *InvalidFieldValueError) message() string {
+	return "invalid value " + describe(e.Value) + " for field " + e.Field.Describe()
+}
+
+// located returns the error message prefixed with the cell location, when known.
+func located(r CellRef, msg string) string {
+	if r.Address() == "" {
+		return "xlsx2struct: " + msg
+	}
+	return "xlsx2struct: " + r.String() + ": " + msg
+}
+
+// locateError sets the cell location of the value errors wrapped by err.
+func locateError(err error, r CellRef) {
+	var uerr *UnsupportedValueError
+	if errors.As(err, &uerr) {
+		uerr.CellRef = r
+	}
+
+	var verr *InvalidFieldValueError
+	if errors.As(err, &verr) {
+		verr.CellRef = r
+	}
+}
+
+func quoteHeadings(hs []string) string {
+	qs := make([]string, len(hs))
+	for i, h := range hs {
+		qs[i] = "'" + h + "'"
+	}
+	return strings.Join(qs, ", ")
 }
 
 func describe(a any) string {
@@ -80,8 +342,24 @@ func describe(a any) string {
 func describeCell(c *xlsx3.Cell) string {
 	s := "nil"
 	if c != nil {
-		x, y := c.GetCoordinates()
-		s = fmt.Sprintf("(%d, %d)[%s]", x, y, c.String())
+		s = fmt.Sprintf("%s[%s]", newCellRef(c, "").Address(), c.String())
 	}
 	return s
 }
+
+// describeError returns the message of err without the package prefix. The message
+// of a strconv.NumError is reduced to its cause, e.g. "invalid syntax".
+func describeError(err error) string {
+	if err == nil {
+		return "nil"
+	}
+	switch e := err.(type) {
+	case *strconv.NumError:
+		return e.Err.Error()
+	case *UnsupportedValueError:
+		return e.message() // location is given by the wrapping error
+	case *InvalidFieldValueError:
+		return e.message()
+	}
+	return strings.TrimPrefix(err.Error(), "xlsx2struct: ")
+}
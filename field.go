@@ -1,27 +1,111 @@
 package xlsx2struct
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	xlsx3 "github.com/tealeg/xlsx/v3"
 )
 
 var defaultTimeFormats = []string{time.DateOnly, time.RFC3339}
 
+// CellUnmarshaler is the interface implemented by types that can unmarshal
+// themselves from a cell. UnmarshalCell is called with the cell after trim and
+// default options have been applied to its value.
+type CellUnmarshaler interface {
+	UnmarshalCell(cell *xlsx3.Cell) error
+}
+
+var (
+	cellUnmarshalerType = reflect.TypeFor[CellUnmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
 type Field struct {
 	reflect.StructField
-	tag columnTag
+	tag       columnTag
+	prefix    string    // heading prefix of the enclosing nested structs
+	converter Converter // converter selected by the conv option
+	rules     []rule    // validation rules from the tag options
+
+	restColumns []*Column    // unmapped columns read by a field with the rest option
+	group       *columnGroup // columns read by a field with the headings or match option
 }
 
+// Heading returns the heading of the column the field is written to and read from.
 func (f Field) Heading() string {
 	if f.tag.heading != "" {
-		return f.tag.heading
+		return f.prefix + f.tag.heading
+	}
+	return f.prefix + f.Name
+}
+
+// Headings returns the heading followed by any alternative headings of the field.
+func (f Field) Headings() []string {
+	hs := []string{f.Heading()}
+	for _, a := range f.tag.aliases {
+		hs = append(hs, f.prefix+a)
+	}
+	return hs
+}
+
+// fixedIndex returns the column index (zero based) set by the col or index option.
+func (f Field) fixedIndex() (int, bool) {
+	if f.tag.col != "" {
+		return xlsx3.ColLettersToIndex(strings.ToUpper(f.tag.col)), true
+	}
+	if f.tag.index != "" {
+		i, _ := strconv.Atoi(f.tag.index)
+		return i, true
+	}
+	return 0, false
+}
+
+// checkFixedIndex checks the values of the col and index options.
+func (f *Field) checkFixedIndex() error {
+	if f.tag.col != "" {
+		for _, r := range f.tag.col {
+			if !unicode.IsLetter(r) || r > unicode.MaxASCII {
+				return &InvalidTagError{Field: f, Option: ColOption + "=" + f.tag.col}
+			}
+		}
+	}
+	if f.tag.index != "" {
+		if i, err := strconv.Atoi(f.tag.index); err != nil || i < 0 {
+			return &InvalidTagError{Field: f, Option: IndexOption + "=" + f.tag.index}
+		}
 	}
-	return f.Name
+	return nil
+}
+
+// virtual reports whether the field is not read from a column, but set from the
+// unpivoted heading, the sheet name or the position of the row.
+func (f Field) virtual() bool {
+	return f.tag.variable || f.tag.sheetName || f.tag.rowNum || f.tag.cells
+}
+
+// nested reports whether the field is a struct whose fields are mapped to columns.
+func (f Field) nested() bool {
+	if f.Type.Kind() != reflect.Struct || f.Type == reflect.TypeOf(time.Time{}) || isUnmarshaler(f.Type) {
+		return false
+	}
+	return f.Anonymous || f.IsExported()
+}
+
+// value returns the field of struct v, following the index path of nested fields.
+func (f *Field) value(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Map {
+		return v // record is its own field
+	}
+	if len(f.Index) == 0 {
+		return v.FieldByName(f.Name)
+	}
+	return v.FieldByIndex(f.Index)
 }
 
 func (f Field) String() string {
@@ -31,10 +115,7 @@ func (f Field) String() string {
 func (f *Field) Describe() string {
 	s := "nil"
 	if f != nil {
-		h := f.Name
-		if f.tag.heading != "" {
-			h = f.tag.heading
-		}
+		h := f.Heading()
 		t := "nil"
 		if f.Type != nil {
 			t = f.Type.String()
@@ -45,16 +126,41 @@ func (f *Field) Describe() string {
 }
 
 // unmarshalField reads field value from given cell. Read flag (ok) is false when default value is returned.
+//
+// Pointer fields are nil when the cell is empty and the field has no default value.
 func unmarshalField(field *Field, cell *xlsx3.Cell) (a any, ok bool, err error) {
+	var heading string
+	if field != nil {
+		heading = field.Heading()
+	}
+	return unmarshalColumn(field, cell, heading)
+}
+
+// unmarshalColumn is like unmarshalField, with errors located in the column with the
+// given heading, i.e. the heading matched in the sheet.
+func unmarshalColumn(field *Field, cell *xlsx3.Cell, heading string) (a any, ok bool, err error) {
 	if field == nil || cell == nil {
 		err = &UnmarshalFieldError{Cell: cell, Field: field}
 		return
 	}
 
+	ref := newCellRef(cell, heading)
 	v := cell.Value
 	ok = true
 
+	t := field.Type
+	ptr := t.Kind() == reflect.Pointer
+	if ptr {
+		t = t.Elem()
+	}
+
 	if v == "" {
+		if ptr && field.tag.defaultValue == "" {
+			if r, valid := validate(field, nil, v); !valid {
+				return nil, false, &ValidationError{CellRef: ref, Field: field, Cell: cell, Value: v, Rule: r}
+			}
+			return reflect.Zero(field.Type).Interface(), false, nil
+		}
 		v = defaultValue(field)
 		ok = false
 	}
@@ -63,12 +169,87 @@ func unmarshalField(field *Field, cell *xlsx3.Cell) (a any, ok bool, err error)
 		v = strings.TrimSpace(v)
 	}
 
+	if field.converter != nil {
+		a, err = convert(field, t, cell, v)
+	} else if isUnmarshaler(t) {
+		a, err = unmarshalInterface(t, cell, v)
+	} else if t.Kind() == reflect.Slice {
+		a, err = unmarshalSlice(field, t, cell, v)
+	} else {
+		a, err = parseValue(field, t, cell.Type(), v)
+	}
+
+	if _, unsupported := err.(*UnsupportedFieldError); unsupported {
+		return nil, ok, err
+	}
+
+	if err != nil {
+		locateError(err, ref)
+		err = &UnmarshalFieldError{CellRef: ref, Cell: cell, Field: field, Value: v, Err: err}
+		return
+	}
+
+	if r, valid := validate(field, a, v); !valid {
+		return nil, ok, &ValidationError{CellRef: ref, Field: field, Cell: cell, Value: v, Rule: r}
+	}
+
+	if ptr {
+		p := reflect.New(t)
+		p.Elem().Set(reflect.ValueOf(a))
+		a = p.Interface()
+	}
+
+	return
+}
+
+// isUnmarshaler reports whether values of type t unmarshal themselves.
+// time.Time is unmarshalled natively to support Excel dates.
+func isUnmarshaler(t reflect.Type) bool {
+	if t == reflect.TypeOf(time.Time{}) {
+		return false
+	}
+	pt := reflect.PointerTo(t)
+	return pt.Implements(cellUnmarshalerType) || pt.Implements(textUnmarshalerType)
+}
+
+// unmarshalInterface unmarshals v using the [CellUnmarshaler] or [encoding.TextUnmarshaler]
+// implementation of type t. An empty value unmarshals to the zero value of t.
+func unmarshalInterface(t reflect.Type, cell *xlsx3.Cell, v string) (any, error) {
+	p := reflect.New(t)
+	if v == "" {
+		return p.Elem().Interface(), nil
+	}
+
+	var err error
+
+	switch u := p.Interface().(type) {
+	case CellUnmarshaler:
+		c := cell
+		if v != cell.Value {
+			cc := *cell
+			cc.Value = v
+			c = &cc
+		}
+		err = u.UnmarshalCell(c)
+	case encoding.TextUnmarshaler:
+		err = u.UnmarshalText([]byte(v))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Elem().Interface(), nil
+}
+
+// parseValue parses v, read from a cell of type ct, into a value of type t.
+func parseValue(field *Field, t reflect.Type, ct xlsx3.CellType, v string) (a any, err error) {
 	var i int64
 	var u uint64
 	var f float64
-	var t time.Time
+	var tm time.Time
 
-	switch field.Type.Kind() {
+	switch t.Kind() {
 	case reflect.Bool:
 		a, err = strconv.ParseBool(v)
 	case reflect.Float32:
@@ -118,29 +299,23 @@ func unmarshalField(field *Field, cell *xlsx3.Cell) (a any, ok bool, err error)
 	case reflect.String:
 		a = v
 	case reflect.Struct:
-		switch field.Type {
+		switch t {
 		case reflect.TypeOf(time.Time{}): // TODO: re-factor unmarshalTimeField
-			switch cell.Type() {
+			switch ct {
 			case xlsx3.CellTypeNumeric:
 				if f, err = strconv.ParseFloat(v, 64); err == nil {
 					a = xlsx3.TimeFromExcelTime(f, false)
 				}
 			default:
-				if t, err = parseTime(v, field.tag.timeFormats...); err == nil {
-					a = t
+				if tm, err = parseTime(v, field.tag.timeFormats...); err == nil {
+					a = tm
 				}
 			}
 		default:
 			err = &UnsupportedFieldError{Field: field}
-			return
 		}
 	default:
 		err = &UnsupportedFieldError{Field: field}
-		return
-	}
-
-	if err != nil {
-		err = &UnmarshalFieldError{Cell: cell, Field: field}
 	}
 
 	return
@@ -165,15 +340,20 @@ func defaultValue(f *Field) string {
 		return ""
 	}
 
+	t := f.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
 	v := f.tag.defaultValue
-	if v == "" {
-		switch f.Type.Kind() {
+	if v == "" && !isUnmarshaler(t) { // unmarshalers read an empty value as their zero value
+		switch t.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 			v = "0"
 		case reflect.Float32, reflect.Float64:
 			v = "0.0"
 		case reflect.Struct:
-			switch f.Type {
+			switch t {
 			case reflect.TypeOf(time.Time{}):
 				v = "0001-01-01"
 			}
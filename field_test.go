@@ -2,7 +2,12 @@ package xlsx2struct
 
 import (
 	"fmt"
+	"math"
+	"net/netip"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -46,7 +51,7 @@ type supportedTypes struct {
 
 	TrimString string `column:"trim"`
 
-	Unsupported []int
+	Unsupported map[string]int
 }
 
 func TestUnmarshalFieldBasicTypes(t *testing.T) {
@@ -104,6 +109,136 @@ func TestUnmarshalField(t *testing.T) {
 	}
 }
 
+type pointerTypes struct {
+	Int     *int
+	Float64 *float64
+	String  *string
+	Time    *time.Time
+	Default *int `column:"default=7"`
+}
+
+func TestUnmarshalFieldPointers(t *testing.T) {
+	fields, err := fields(pointerTypes{})
+	require.NoError(t, err)
+
+	// empty cells are nil
+	for _, n := range []string{"Int", "Float64", "String", "Time"} {
+		v, ok, err := unmarshalField(fields[n], cell(""))
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.True(t, reflect.ValueOf(v).IsNil())
+		require.Equal(t, fields[n].Type, reflect.TypeOf(v))
+	}
+
+	// default value
+	v, ok, err := unmarshalField(fields["Default"], cell(""))
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, 7, *v.(*int))
+
+	// zero values
+	v, ok, err = unmarshalField(fields["Int"], cell("0"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 0, *v.(*int))
+
+	v, _, err = unmarshalField(fields["Time"], cell("2025-01-02"))
+	require.NoError(t, err)
+	require.Equal(t, "2025-01-02", v.(*time.Time).Format(time.DateOnly))
+
+	// invalid value
+	_, _, err = unmarshalField(fields["Float64"], cell("abc"))
+	require.ErrorIs(t, err, strconv.ErrSyntax)
+}
+
+// money is stored in cents and read from cells such as "$12.34".
+type money int64
+
+func (m *money) UnmarshalCell(cell *xlsx3.Cell) error {
+	f, err := strconv.ParseFloat(strings.TrimPrefix(cell.Value, "$"), 64)
+	if err != nil {
+		return err
+	}
+	*m = money(math.Round(f * 100))
+	return nil
+}
+
+func (m money) MarshalCell(cell *xlsx3.Cell) error {
+	cell.SetString(fmt.Sprintf("$%d.%02d", m/100, m%100))
+	return nil
+}
+
+// level is read from and written as its name.
+type level int
+
+var levelNames = []string{"low", "high"}
+
+func (l *level) UnmarshalText(b []byte) error {
+	i := slices.Index(levelNames, string(b))
+	if i < 0 {
+		return fmt.Errorf("bad level %s", b)
+	}
+	*l = level(i + 1)
+	return nil
+}
+
+func (l level) MarshalText() ([]byte, error) {
+	if l < 1 || int(l) > len(levelNames) {
+		return nil, fmt.Errorf("bad level %d", l)
+	}
+	return []byte(levelNames[l-1]), nil
+}
+
+type unmarshalerTypes struct {
+	Money    money
+	MoneyPtr *money
+	Addr     netip.Addr
+}
+
+func TestUnmarshalFieldUnmarshalers(t *testing.T) {
+	fields, err := fields(unmarshalerTypes{})
+	require.NoError(t, err)
+
+	v, _, err := unmarshalField(fields["Money"], cell("$12.34"))
+	require.NoError(t, err)
+	require.Equal(t, money(1234), v)
+
+	v, _, err = unmarshalField(fields["MoneyPtr"], cell("$1.50"))
+	require.NoError(t, err)
+	require.Equal(t, money(150), *v.(*money))
+
+	v, _, err = unmarshalField(fields["Addr"], cell("10.0.0.1"))
+	require.NoError(t, err)
+	require.Equal(t, netip.MustParseAddr("10.0.0.1"), v)
+
+	// empty cell
+	v, ok, err := unmarshalField(fields["Addr"], cell(""))
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, netip.Addr{}, v)
+
+	// numeric kinds get no kind default
+	ls, err := extractFields(reflect.TypeOf(struct {
+		Level   level
+		Default level `column:"default=high"`
+	}{}))
+	require.NoError(t, err)
+	v, ok, err = unmarshalField(ls[0], cell(""))
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, level(0), v)
+	v, _, err = unmarshalField(ls[1], cell(""))
+	require.NoError(t, err)
+	require.Equal(t, level(2), v)
+
+	// errors are wrapped
+	_, _, err = unmarshalField(fields["Money"], cell("abc"))
+	require.ErrorIs(t, err, strconv.ErrSyntax)
+	_, _, err = unmarshalField(fields["Addr"], cell("abc"))
+	var ferr *UnmarshalFieldError
+	require.ErrorAs(t, err, &ferr)
+}
+
 func fields(a any) (map[string]*Field, error) {
 	fs, err := extractFields(reflect.TypeOf(a))
 	if err != nil {
@@ -0,0 +1,240 @@
+package xlsx2struct
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+// columnGroup describes the columns gathered by a field with the headings or match option.
+type columnGroup struct {
+	first, last string         // heading range "first..last"
+	headings    []string       // heading list "a|b|c"
+	re          *regexp.Regexp // heading pattern of the match option
+	elem        *Field         // reads and writes the elements of the field
+	variable    *Field         // receives the heading of the cells of a value field
+
+	columns []*Column // columns of the group in sheet order
+}
+
+// newColumnGroup returns the column group of a field with the headings, match or value
+// option, or nil when the field has none. Except for value fields, the field type must
+// be a slice or a map with string keys.
+func newColumnGroup(f *Field) (*columnGroup, error) {
+	if f.tag.headings == "" && f.tag.match == "" && !f.tag.value {
+		return nil, nil
+	}
+
+	opt := HeadingsOption + "=" + f.tag.headings
+	if f.tag.match != "" {
+		opt = MatchOption + "=" + f.tag.match
+	} else if f.tag.headings == "" {
+		opt = ValueOption
+	}
+
+	t := f.Type
+	if f.tag.headings != "" && f.tag.match != "" || f.tag.rest || !f.tag.value &&
+		t.Kind() != reflect.Slice && (t.Kind() != reflect.Map || t.Key().Kind() != reflect.String) {
+		return nil, &InvalidTagError{Field: f, Option: opt}
+	}
+
+	g := &columnGroup{}
+
+	if f.tag.match != "" {
+		re, err := regexp.Compile(f.tag.match)
+		if err != nil {
+			return nil, &InvalidTagError{Field: f, Option: opt, Err: err}
+		}
+		g.re = re
+	} else if first, last, ok := strings.Cut(f.tag.headings, ".."); ok {
+		g.first, g.last = strings.TrimSpace(first), strings.TrimSpace(last)
+	} else if f.tag.headings != "" {
+		g.headings = strings.Split(f.tag.headings, "|")
+	}
+
+	elem := *f
+	if !f.tag.value {
+		elem.Type = t.Elem()
+	}
+	elem.tag.headings, elem.tag.match, elem.tag.value = "", "", false
+	if err := compileRules(&elem); err != nil {
+		return nil, err
+	}
+	g.elem = &elem
+
+	return g, nil
+}
+
+// unmapped reports whether the group has no headings or pattern and so gathers the
+// columns not mapped to other fields.
+func (g *columnGroup) unmapped() bool {
+	return g.re == nil && g.headings == nil && g.first == ""
+}
+
+// String returns the headings or pattern of the group as given in the tag.
+func (g *columnGroup) String() string {
+	switch {
+	case g.re != nil:
+		return g.re.String()
+	case g.headings != nil:
+		return strings.Join(g.headings, "|")
+	case g.unmapped():
+		return ""
+	}
+	return g.first + ".." + g.last
+}
+
+// match returns the columns of the group in sheet order.
+func (g *columnGroup) match(columns []*Column, match HeadingMatch) []*Column {
+	cs := []*Column{}
+
+	switch {
+	case g.re != nil:
+		for _, c := range columns {
+			if g.re.MatchString(strings.TrimSpace(c.Heading)) {
+				cs = append(cs, c)
+			}
+		}
+	case g.headings != nil:
+		keys := map[string]bool{}
+		for _, h := range g.headings {
+			keys[match.key(h)] = true
+		}
+		for _, c := range columns {
+			if keys[match.key(c.Heading)] {
+				cs = append(cs, c)
+			}
+		}
+	default:
+		first := columnIndex(columns, g.first, match)
+		if first < 0 {
+			return cs
+		}
+		last := columnIndex(columns[first:], g.last, match)
+		if last < 0 {
+			return cs
+		}
+		cs = append(cs, columns[first:first+last+1]...)
+	}
+
+	return cs
+}
+
+// columnIndex returns the index of the first column matching heading, or -1.
+func columnIndex(columns []*Column, heading string, match HeadingMatch) int {
+	key := match.key(heading)
+	for i, c := range columns {
+		if match.key(c.Heading) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// key returns the map key of the column with the given heading: the first capture group of the match option
+// when the pattern has one, otherwise the heading.
+func (g *columnGroup) key(heading string) string {
+	if g.re != nil && g.re.NumSubexp() > 0 {
+		if m := g.re.FindStringSubmatch(strings.TrimSpace(heading)); m != nil {
+			return m[1]
+		}
+	}
+	return heading
+}
+
+// elemField returns the element field reading and writing the column with the given heading.
+func (g *columnGroup) elemField(heading string) *Field {
+	f := *g.elem
+	f.prefix, f.tag.heading = "", heading
+	return &f
+}
+
+// unmarshalGroup reads the columns of the field's group into a slice, in sheet order, or
+// into a map keyed by heading or capture group. Empty cells are left out of a map unless
+// the field has a default value. The flag is false when all cells are empty.
+func unmarshalGroup(field *Field, sheet *xlsx3.Sheet, row int) (any, bool, error) {
+	g := field.group
+	isMap := field.Type.Kind() == reflect.Map
+
+	var s reflect.Value
+	if isMap {
+		s = reflect.MakeMapWithSize(field.Type, len(g.columns))
+	} else {
+		s = reflect.MakeSlice(field.Type, 0, len(g.columns))
+	}
+
+	allOk := false
+	errs := []error{}
+
+	for _, col := range g.columns {
+		c, err := sheet.Cell(row, col.Index)
+		if err != nil {
+			c = &xlsx3.Cell{}
+		}
+
+		a, ok, err := unmarshalField(g.elemField(col.Heading), c)
+		allOk = allOk || ok
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		e := reflect.ValueOf(a)
+		if !e.IsValid() {
+			e = reflect.Zero(g.elem.Type)
+		}
+
+		if !isMap {
+			s = reflect.Append(s, e)
+		} else if ok || field.tag.defaultValue != "" {
+			s.SetMapIndex(reflect.ValueOf(g.key(col.Heading)).Convert(field.Type.Key()), e)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, allOk, errors.Join(errs...)
+	}
+
+	return s.Interface(), allOk, nil
+}
+
+// groupHeadings returns the headings the group field of the structs in v is written to.
+// These are the listed headings of the headings option, or the map keys when the field
+// is a map gathered by a pattern without capture groups. Headings of other groups
+// cannot be derived from the field values.
+func groupHeadings(field *Field, v reflect.Value) ([]string, error) {
+	g := field.group
+	if field.tag.value {
+		return nil, &UnsupportedFieldError{Field: field} // unpivoted columns are not written
+	}
+
+	switch {
+	case g.headings != nil:
+		return g.headings, nil
+	case g.re != nil && g.re.NumSubexp() == 0 && field.Type.Kind() == reflect.Map:
+		return restHeadings(field, v), nil
+	}
+
+	return nil, &UnsupportedFieldError{Field: field}
+}
+
+// marshalGroup writes the element of group field value v at index i, or with the given
+// heading as key, to the cell.
+func marshalGroup(field *Field, v reflect.Value, i int, heading string, cell *xlsx3.Cell) error {
+	var e reflect.Value
+
+	if v.Kind() == reflect.Map {
+		e = v.MapIndex(reflect.ValueOf(heading).Convert(v.Type().Key()))
+	} else if i < v.Len() {
+		e = v.Index(i)
+	}
+
+	if !e.IsValid() {
+		return nil
+	}
+
+	return marshalField(field.group.elemField(heading), e, cell)
+}
@@ -0,0 +1,161 @@
+package xlsx2struct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalGroupRange(t *testing.T) {
+	type Struct1 struct {
+		Region  string    `column:"heading=Region"`
+		Monthly []float64 `column:"headings=Jan..Mar"`
+		Total   float64   `column:"heading=Total"`
+	}
+
+	sheet := sheetOf(t,
+		[]string{"Region", "Jan", "Feb", "Mar", "Total"},
+		[]string{"North", "1", "", "3.5", "4.5"},
+		[]string{"South", "2", "2", "2", "6"},
+	)
+
+	a := []Struct1{}
+	err := Unmarshal(sheet, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Struct1{
+		{Region: "North", Monthly: []float64{1, 0, 3.5}, Total: 4.5},
+		{Region: "South", Monthly: []float64{2, 2, 2}, Total: 6},
+	}, a)
+
+	// group consumes its columns
+	opt := DefaultSheetOptions()
+	opt.DisallowExtraColumns = true
+	err = Unmarshal(sheet, &a, opt)
+	require.NoError(t, err)
+}
+
+func TestUnmarshalGroupMatch(t *testing.T) {
+	type Struct1 struct {
+		Name   string         `column:"heading=Name"`
+		Scores map[string]int `column:"match=^Score (\\d+)$"`
+	}
+
+	type Struct2 struct {
+		Name   string         `column:"heading=Name"`
+		Scores []int          `column:"match=^Score \\d+$"`
+		ByName map[string]int `column:"match=^Score \\d+$"`
+	}
+
+	sheet := sheetOf(t,
+		[]string{"Name", "Score 1", "Comment", "Score 2"},
+		[]string{"a", "7", "x", ""},
+		[]string{"b", "8", "", "9"},
+	)
+
+	a := []Struct1{}
+	err := Unmarshal(sheet, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"1": 7}, a[0].Scores)
+	require.Equal(t, map[string]int{"1": 8, "2": 9}, a[1].Scores)
+
+	b := []Struct2{}
+	err = Unmarshal(sheet, &b, nil)
+	require.NoError(t, err)
+	require.Equal(t, []int{7, 0}, b[0].Scores)
+	require.Equal(t, map[string]int{"Score 1": 8, "Score 2": 9}, b[1].ByName)
+
+	// heading match flags do not apply to patterns
+	type Struct3 struct {
+		Name   string `column:"heading=name"`
+		Exact  []int  `column:"match=^score \\d+$"`
+		Folded []int  `column:"match=(?i)^score \\d+$"`
+	}
+
+	opt := DefaultSheetOptions()
+	opt.HeadingMatch = MatchFoldCase
+	c := []Struct3{}
+	err = Unmarshal(sheet, &c, opt)
+	require.NoError(t, err)
+	require.Empty(t, c[1].Exact)
+	require.Equal(t, []int{8, 9}, c[1].Folded)
+}
+
+func TestUnmarshalGroupErrors(t *testing.T) {
+	type Struct1 struct {
+		Monthly []int `column:"headings=Jan|Feb,min=0"`
+	}
+
+	sheet := sheetOf(t,
+		[]string{"Jan", "Feb"},
+		[]string{"1", "x"},
+		[]string{"-1", "2"},
+	)
+
+	a := []Struct1{}
+	err := Unmarshal(sheet, &a, nil)
+	require.EqualError(t, err, `xlsx2struct: sheet 'Sheet1', cell B2 (column 'Feb'): cannot unmarshal 'x' into field 'Monthly' (type: int, column: 'Feb'): invalid syntax`)
+
+	opt := DefaultSheetOptions()
+	opt.Lenient = true
+	err = Unmarshal(sheet, &a, opt)
+	var errs *UnmarshalErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs.Errors, 2)
+	require.IsType(t, &ValidationError{}, errs.Errors[1])
+
+	// missing group
+	type Struct2 struct {
+		Monthly []int `column:"headings=Apr..Jun,required"`
+	}
+	err = Unmarshal(sheet, &[]Struct2{}, nil)
+	require.EqualError(t, err, `xlsx2struct: sheet 'Sheet1' is missing column(s) 'Apr..Jun'`)
+
+	_, err = fields(struct {
+		Monthly int `column:"headings=Jan..Dec"`
+	}{})
+	require.EqualError(t, err, `xlsx2struct: invalid option "headings=Jan..Dec" for field 'Monthly' (type: int, column: 'Monthly')`)
+
+	_, err = fields(struct {
+		Scores []int `column:"match=(["`
+	}{})
+	require.ErrorContains(t, err, `xlsx2struct: invalid option "match=([" for field 'Scores'`)
+
+	// options are separated by commas, so patterns cannot contain one
+	_, err = fields(struct {
+		Scores []int `column:"match=^Score (\\d{1,2})$"`
+	}{})
+	require.IsType(t, &InvalidTagError{}, err)
+}
+
+func TestMarshalGroup(t *testing.T) {
+	type Struct1 struct {
+		Region  string         `column:"heading=Region"`
+		Monthly []float64      `column:"headings=Jan|Feb|Mar"`
+		Extra   map[string]int `column:"match=^Q\\d$"`
+	}
+
+	items := []Struct1{
+		{Region: "North", Monthly: []float64{1, 0, 3.5}, Extra: map[string]int{"Q1": 5}},
+		{Region: "South", Monthly: []float64{2, 2, 2}, Extra: map[string]int{"Q2": 6}},
+	}
+
+	out := newSheet(t)
+	err := Marshal(out, items, nil)
+	require.NoError(t, err)
+
+	for i, h := range []string{"Region", "Jan", "Feb", "Mar", "Q1", "Q2"} {
+		c, _ := out.Cell(0, i)
+		require.Equal(t, h, c.Value)
+	}
+
+	a := []Struct1{}
+	err = Unmarshal(out, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, items, a)
+
+	type Struct2 struct {
+		Monthly []float64 `column:"headings=Jan..Dec"`
+	}
+	err = Marshal(out, []Struct2{{}}, nil)
+	require.IsType(t, &UnsupportedFieldError{}, err)
+}
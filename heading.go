@@ -0,0 +1,157 @@
+package xlsx2struct
+
+import (
+	"strings"
+
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+// HeadingMatch controls how column headings are matched to field headings.
+// Flags can be combined, e.g. MatchFoldCase|MatchNormalizeSpace. They apply to the
+// heading and headings options, but not to the patterns of the match option.
+type HeadingMatch int
+
+const (
+	// MatchExact matches headings that are exactly equal.
+	MatchExact HeadingMatch = 0
+
+	// MatchFoldCase matches headings regardless of case.
+	MatchFoldCase HeadingMatch = 1 << iota
+
+	// MatchNormalizeSpace trims headings and treats any run of white space,
+	// including line breaks, as a single space.
+	MatchNormalizeSpace
+)
+
+// key returns the heading h as compared under match mode m.
+func (m HeadingMatch) key(h string) string {
+	if m&MatchNormalizeSpace != 0 {
+		h = strings.Join(strings.Fields(h), " ")
+	}
+	if m&MatchFoldCase != 0 {
+		h = strings.ToLower(h)
+	}
+	return h
+}
+
+// DefaultHeadingSeparator joins the headings of grouped heading rows.
+const DefaultHeadingSeparator = "/"
+
+func (opt *SheetOptions) headingSeparator() string {
+	if opt.HeadingSeparator != "" {
+		return opt.HeadingSeparator
+	}
+	return DefaultHeadingSeparator
+}
+
+// headingColumns extracts the columns of the heading row, or rows, starting at row and col.
+func headingColumns(sheet *xlsx3.Sheet, row, col int, opt *SheetOptions) ([]*Column, error) {
+	if opt.HeadingDepth > 1 {
+		return extractGroupedColumns(sheet, row, col, opt.HeadingDepth, opt.headingSeparator())
+	}
+	return extractColumns(sheet, row, col)
+}
+
+// extractGroupedColumns extracts columns with headings spanning depth rows. The heading of
+// a column joins the headings found in each row with sep, e.g. "Q1/Revenue". The value of
+// a merged cell applies to all the columns it spans, and a cell merged vertically is used
+// once. Columns end at the first column where all heading rows are empty.
+func extractGroupedColumns(sheet *xlsx3.Sheet, row, col, depth int, sep string) ([]*Column, error) {
+	if sheet == nil || row < 0 || col < 0 {
+		return nil, nil
+	}
+
+	// horizontally merged cells, per heading row: last column spanned and heading
+	spanEnd := make([]int, depth)
+	spanHeading := make([]string, depth)
+	for i := range spanEnd {
+		spanEnd[i] = -1
+	}
+
+	cols := []*Column{}
+
+	for ; ; col++ {
+		parts := []string{}
+		vEnd := -1 // last heading row of a vertically merged cell in this column
+
+		for i := 0; i < depth; i++ {
+			c, err := sheet.Cell(row+i, col)
+			if err != nil {
+				return nil, err
+			}
+
+			switch {
+			case strings.TrimSpace(c.Value) != "":
+				parts = append(parts, c.Value)
+				if c.VMerge > 0 {
+					vEnd = i + c.VMerge
+				}
+				for k := i; k < depth && k <= max(i, vEnd); k++ {
+					spanEnd[k] = col + c.HMerge
+					spanHeading[k] = ""
+				}
+				spanHeading[i] = c.Value
+			case i <= vEnd:
+				// spanned by the vertically merged cell above
+			case col <= spanEnd[i] && spanHeading[i] != "":
+				parts = append(parts, spanHeading[i])
+			}
+		}
+
+		if len(parts) == 0 {
+			break
+		}
+
+		cols = append(cols, &Column{Heading: strings.Join(parts, sep), Index: col})
+	}
+
+	return cols, nil
+}
+
+// writeGroupedHeadings writes headings over depth rows starting at row, splitting each
+// heading with sep. Adjacent columns sharing a parent heading are merged horizontally, and
+// a heading with fewer parts than depth is merged vertically down to the last heading row.
+func writeGroupedHeadings(sheet *xlsx3.Sheet, row int, cols []int, headings []string, depth int, sep string) error {
+	type span struct {
+		cell *xlsx3.Cell
+		key  string
+		col  int
+	}
+
+	open := make([]span, depth)
+
+	for j, h := range headings {
+		parts := strings.SplitN(h, sep, depth)
+
+		for i, p := range parts {
+			leaf := i == len(parts)-1
+			key := strings.Join(parts[:i+1], sep)
+
+			if s := open[i]; !leaf && s.cell != nil && s.key == key && s.col == cols[j]-1 {
+				s.cell.Merge(s.cell.HMerge+1, 0)
+				open[i].col = cols[j]
+				continue
+			}
+
+			c, err := sheet.Cell(row+i, cols[j])
+			if err != nil {
+				return err
+			}
+			c.SetString(p)
+
+			if leaf {
+				if len(parts) < depth {
+					c.Merge(0, depth-len(parts))
+				}
+			} else {
+				open[i] = span{cell: c, key: key, col: cols[j]}
+			}
+		}
+
+		for i := len(parts) - 1; i < depth; i++ {
+			open[i] = span{}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package xlsx2struct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+func TestHeadingMatchKey(t *testing.T) {
+	require.Equal(t, " Order\nDate ", MatchExact.key(" Order\nDate "))
+	require.Equal(t, "Order Date", MatchNormalizeSpace.key(" Order\n\tDate "))
+	require.Equal(t, " order date", MatchFoldCase.key(" Order Date"))
+	require.Equal(t, "order date", (MatchFoldCase | MatchNormalizeSpace).key(" ORDER \r\n Date"))
+}
+
+type quarterly struct {
+	Region    string  `column:"heading=Region"`
+	Q1Revenue float64 `column:"heading=Q1/Revenue"`
+	Q1Cost    float64 `column:"heading=Q1/Cost"`
+	Q2Revenue float64 `column:"heading=Q2/Revenue"`
+	Q2Cost    float64 `column:"heading=Q2/Cost"`
+}
+
+func TestExtractGroupedColumns(t *testing.T) {
+	sheet := sheetOf(t,
+		[]string{"Region", "Q1", "", "Q2", "", "Notes"},
+		[]string{"", "Revenue", "Cost", "Revenue", "Cost", ""},
+		[]string{"East", "10", "5", "20", "8", "n"},
+	)
+	merge(t, sheet, 0, 0, 0, 1)
+	merge(t, sheet, 0, 1, 1, 0)
+	merge(t, sheet, 0, 3, 1, 0)
+
+	cols, err := extractGroupedColumns(sheet, 0, 0, 2, "/")
+	require.NoError(t, err)
+
+	hs := []string{}
+	for _, c := range cols {
+		hs = append(hs, c.Heading)
+	}
+	require.Equal(t, []string{"Region", "Q1/Revenue", "Q1/Cost", "Q2/Revenue", "Q2/Cost", "Notes"}, hs)
+
+	opt := DefaultSheetOptions()
+	opt.HeadingDepth = 2
+	opt.DataRow = 2
+
+	a := []quarterly{}
+	err = Unmarshal(sheet, &a, opt)
+	require.NoError(t, err)
+	require.Equal(t, []quarterly{{"East", 10, 5, 20, 8}}, a)
+
+	// round trip
+	out := newSheet(t)
+	err = Marshal(out, a, opt)
+	require.NoError(t, err)
+
+	c, _ := out.Cell(0, 0)
+	require.Equal(t, 1, c.VMerge)
+	c, _ = out.Cell(0, 1)
+	require.Equal(t, "Q1", c.Value)
+	require.Equal(t, 1, c.HMerge)
+	c, _ = out.Cell(0, 2)
+	require.Equal(t, "", c.Value)
+
+	b := []quarterly{}
+	err = Unmarshal(out, &b, opt)
+	require.NoError(t, err)
+	require.Equal(t, a, b)
+}
+
+func merge(t *testing.T, sheet *xlsx3.Sheet, row, col, h, v int) {
+	c, err := sheet.Cell(row, col)
+	require.NoError(t, err)
+	c.Merge(h, v)
+}
@@ -0,0 +1,307 @@
+package xlsx2struct
+
+import (
+	"encoding"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+// CellMarshaler is the interface implemented by types that can marshal
+// themselves into a cell.
+type CellMarshaler interface {
+	MarshalCell(cell *xlsx3.Cell) error
+}
+
+// Marshal writes the slice of struct a to the sheet. Headings are written
+// to the row and column given by opt, and data is written starting at the
+// first data row. If a is not a slice (or a pointer to a slice) of struct or
+// record (see [Unmarshal]), Marshal returns an [InvalidMarshalError].
+//
+// Marshal uses the same struct field tags as [Unmarshal]:
+//
+//	// Column heading is "Order Date" and values are written as Excel
+//	// dates formatted as "yyyy-mm-dd".
+//	Date time.Time `column:"heading=Order Date,time=2006-01-02"`
+//
+//	// Values equal to the default value are written like any other value, so
+//	// rows holding only default values are not read back as empty rows.
+//	Units int32 `column:"heading=Units,default=1"`
+//
+// Numbers are written as numeric cells and time.Time values as Excel dates.
+// Types implementing [CellMarshaler] or [encoding.TextMarshaler] marshal themselves.
+// Nil struct pointers in a are skipped and nil pointer fields are left empty.
+// Fields set from the sheet or row, such as those with the rownum option, are not written.
+// Fields with the conv option are written unconverted, see [Converter].
+// The keys of a field with the rest option are written as additional columns
+// after the columns of the other fields. A field with the headings option is written
+// to one column per listed heading, and a map field with the match option to one
+// column per key. Other fields gathering a group of columns return an
+// [UnsupportedFieldError], as their headings cannot be derived from the field values.
+func Marshal(sheet *xlsx3.Sheet, a any, opt *SheetOptions) error {
+	v := reflect.ValueOf(a)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return &InvalidMarshalError{reflect.TypeOf(a)}
+	}
+
+	if sheet == nil {
+		return nil
+	}
+
+	if opt == nil {
+		opt = DefaultSheetOptions()
+	}
+
+	if s, _ := getStructType(v.Type().Elem()); s == nil && !isRecordType(v.Type().Elem()) {
+		return &InvalidMarshalError{reflect.TypeOf(a)}
+	}
+
+	fields, err := extractFields(v.Type().Elem())
+	if err != nil {
+		return err
+	}
+
+	var rest *Field
+	var restKeys []string
+
+	fs := make([]*Field, 0, len(fields))
+	for _, f := range fields {
+		if f.tag.rest {
+			rest = f
+			continue
+		}
+		if f.virtual() {
+			continue // not read from a column
+		}
+		fs = append(fs, f)
+	}
+
+	if rest != nil {
+		restKeys = restHeadings(rest, v)
+	}
+
+	// fields with the col or index option are written to their fixed column,
+	// other fields, group and rest keys to the next free column
+	fixed := map[int]bool{}
+	for _, f := range fs {
+		if c, ok := f.fixedIndex(); ok && f.group == nil {
+			fixed[c] = true
+		}
+	}
+
+	next := opt.Col
+	nextCol := func() int {
+		for fixed[next] {
+			next++
+		}
+		next++
+		return next - 1
+	}
+
+	cols := []int{}
+	headings := []string{}
+	writers := []func(s reflect.Value, c *xlsx3.Cell) error{}
+
+	for _, f := range fs {
+		if f.group != nil {
+			hs, err := groupHeadings(f, v)
+			if err != nil {
+				return err
+			}
+			for i, h := range hs {
+				cols = append(cols, nextCol())
+				headings = append(headings, h)
+				writers = append(writers, func(s reflect.Value, c *xlsx3.Cell) error {
+					return marshalGroup(f, f.value(s), i, h, c)
+				})
+			}
+			continue
+		}
+
+		c, ok := f.fixedIndex()
+		if !ok {
+			c = nextCol()
+		}
+		cols = append(cols, c)
+		headings = append(headings, f.Heading())
+		writers = append(writers, func(s reflect.Value, c *xlsx3.Cell) error {
+			return marshalField(f, f.value(s), c)
+		})
+	}
+	for _, k := range restKeys {
+		cols = append(cols, nextCol())
+		headings = append(headings, k)
+		writers = append(writers, func(s reflect.Value, c *xlsx3.Cell) error {
+			marshalRest(rest.value(s), k, c)
+			return nil
+		})
+	}
+
+	if opt.HeadingDepth > 1 && !opt.NoHeadings {
+		if err := writeGroupedHeadings(sheet, opt.Row, cols, headings, opt.HeadingDepth, opt.headingSeparator()); err != nil {
+			return err
+		}
+	} else if !opt.NoHeadings {
+		for i, h := range headings {
+			c, err := sheet.Cell(opt.Row, cols[i])
+			if err != nil {
+				return err
+			}
+			c.SetString(h)
+		}
+	}
+
+	row := opt.DataRow
+
+	for i := 0; i < v.Len(); i++ {
+		s := v.Index(i)
+		if s.Kind() == reflect.Pointer {
+			if s.IsNil() {
+				continue
+			}
+			s = s.Elem()
+		}
+
+		for j, write := range writers {
+			c, err := sheet.Cell(row, cols[j])
+			if err != nil {
+				return err
+			}
+
+			if err := write(s, c); err != nil {
+				return err
+			}
+		}
+
+		row += 1
+	}
+
+	return nil
+}
+
+// marshalField writes field value v to the given cell.
+func marshalField(field *Field, v reflect.Value, cell *xlsx3.Cell) error {
+	if field == nil || cell == nil {
+		return &MarshalFieldError{Cell: cell, Field: field}
+	}
+
+	if !field.IsExported() {
+		return &InvalidFieldError{Field: field}
+	}
+
+	t := field.Type
+	if t.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		t = t.Elem()
+		v = v.Elem()
+	}
+
+	if t != reflect.TypeOf(time.Time{}) {
+		m := v.Interface()
+		if v.CanAddr() {
+			m = v.Addr().Interface() // include methods with pointer receivers
+		}
+
+		switch m := m.(type) {
+		case CellMarshaler:
+			return m.MarshalCell(cell)
+		case encoding.TextMarshaler:
+			b, err := m.MarshalText()
+			if err != nil {
+				return err
+			}
+			cell.SetString(string(b))
+			return nil
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		cell.SetBool(v.Bool())
+	case reflect.Float32:
+		cell.SetNumeric(strconv.FormatFloat(v.Float(), 'f', -1, 32))
+	case reflect.Float64:
+		cell.SetNumeric(strconv.FormatFloat(v.Float(), 'f', -1, 64))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		cell.SetInt64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		cell.SetNumeric(strconv.FormatUint(v.Uint(), 10))
+	case reflect.String:
+		cell.SetString(v.String())
+	case reflect.Slice:
+		return marshalSlice(field, v, cell)
+	case reflect.Struct:
+		switch t {
+		case reflect.TypeOf(time.Time{}):
+			tm := v.Interface().(time.Time)
+			if tm.IsZero() {
+				return nil // zero time is read back from an empty cell
+			}
+			cell.SetDateWithOptions(tm, xlsx3.DateTimeOptions{
+				Location:        tm.Location(),
+				ExcelTimeFormat: excelTimeFormat(tm, field.tag.timeFormats...),
+			})
+		default:
+			return &UnsupportedFieldError{Field: field}
+		}
+	default:
+		return &UnsupportedFieldError{Field: field}
+	}
+
+	return nil
+}
+
+// Go layout elements and their Excel number format equivalents, longest first.
+var excelTimeElements = []struct {
+	layout string
+	excel  string
+}{
+	{"January", "mmmm"}, {"Monday", "dddd"}, {"Jan", "mmm"}, {"Mon", "ddd"},
+	{"2006", "yyyy"}, {"Z07:00", ""}, {"-07:00", ""}, {"Z0700", ""}, {"-0700", ""}, {"MST", ""},
+	{"06", "yy"}, {"01", "mm"}, {"02", "dd"}, {"_2", "d"}, {"15", "hh"}, {"03", "hh"}, {"04", "mm"}, {"05", "ss"},
+	{"PM", "AM/PM"}, {"pm", "AM/PM"}, {"1", "m"}, {"2", "d"}, {"3", "h"}, {"4", "m"}, {"5", "s"},
+}
+
+// excelTimeFormat returns the Excel number format for the first Go time layout.
+// Without a layout, a date or date-time format is chosen based on t.
+func excelTimeFormat(t time.Time, layouts ...string) string {
+	if len(layouts) == 0 {
+		if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+			return xlsx3.DefaultDateFormat
+		}
+		return xlsx3.DefaultDateTimeFormat
+	}
+
+	l := layouts[0]
+	var b strings.Builder
+
+	for len(l) > 0 {
+		found := false
+		for _, e := range excelTimeElements {
+			if strings.HasPrefix(l, e.layout) {
+				b.WriteString(e.excel)
+				l = l[len(e.layout):]
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			if ch := l[0]; (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(l[0])
+			l = l[1:]
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
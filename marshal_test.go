@@ -0,0 +1,163 @@
+package xlsx2struct
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+func TestMarshal(t *testing.T) {
+	sheet, opt := openSalesOrdersSheet(t)
+
+	orders := []*SaleOrder{}
+	err := Unmarshal(sheet, &orders, opt)
+	require.NoError(t, err)
+
+	out := newSheet(t)
+	err = Marshal(out, orders, opt)
+	require.NoError(t, err)
+
+	// headings
+	c, _ := out.Cell(0, 5)
+	require.Equal(t, "Unit Cost", c.Value)
+
+	// numbers and dates
+	c, _ = out.Cell(1, 0)
+	require.Equal(t, xlsx3.CellTypeNumeric, c.Type())
+	require.True(t, c.IsTime())
+	c, _ = out.Cell(1, 4)
+	require.Equal(t, xlsx3.CellTypeNumeric, c.Type())
+	require.Equal(t, "95", c.Value)
+
+	// default values are written
+	c, _ = out.Cell(2, 4)
+	require.Equal(t, "1", c.Value)
+
+	a := []*SaleOrder{}
+	err = Unmarshal(out, &a, opt)
+	require.NoError(t, err)
+	require.Equal(t, orders, a)
+}
+
+func TestMarshalDefaultValues(t *testing.T) {
+	type Struct1 struct {
+		Item  string `column:"heading=Item,default=Pencil"`
+		Units int    `column:"heading=Units,default=1"`
+	}
+
+	// row of default values between other rows
+	a := []Struct1{{"Pen", 2}, {"Pencil", 1}, {"Binder", 3}}
+
+	out := newSheet(t)
+	err := Marshal(out, a, nil)
+	require.NoError(t, err)
+
+	c, _ := out.Cell(2, 0)
+	require.Equal(t, "Pencil", c.Value)
+
+	b := []Struct1{}
+	err = Unmarshal(out, &b, nil)
+	require.NoError(t, err)
+	require.Equal(t, a, b)
+}
+
+func TestMarshalTimeFormat(t *testing.T) {
+	type Struct1 struct {
+		Date time.Time `column:"heading=Date,time=2006-01-02"`
+		Time time.Time `column:"heading=Time"`
+	}
+
+	out := newSheet(t)
+	d := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	err := Marshal(out, &[]Struct1{{Date: d, Time: d}}, nil)
+	require.NoError(t, err)
+
+	c, _ := out.Cell(1, 0)
+	require.Equal(t, "yyyy-mm-dd", c.NumFmt)
+	c, _ = out.Cell(1, 1)
+	require.Equal(t, xlsx3.DefaultDateTimeFormat, c.NumFmt)
+
+	a := []Struct1{}
+	err = Unmarshal(out, &a, nil)
+	require.NoError(t, err)
+	require.WithinDuration(t, d, a[0].Time, time.Millisecond)
+}
+
+func TestMarshalPointers(t *testing.T) {
+	type Struct1 struct {
+		Name  string `column:"heading=Name"`
+		Units *int   `column:"heading=Units"`
+	}
+
+	n := 0
+	items := []Struct1{{Name: "a", Units: &n}, {Name: "b"}}
+
+	out := newSheet(t)
+	err := Marshal(out, items, nil)
+	require.NoError(t, err)
+
+	c, _ := out.Cell(1, 1)
+	require.Equal(t, "0", c.Value)
+	c, _ = out.Cell(2, 1)
+	require.Equal(t, "", c.Value)
+
+	a := []Struct1{}
+	err = Unmarshal(out, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, items, a)
+}
+
+func TestMarshalMarshalers(t *testing.T) {
+	items := []unmarshalerTypes{{Money: 1234, Addr: netip.MustParseAddr("10.0.0.1")}}
+
+	out := newSheet(t)
+	err := Marshal(out, items, nil)
+	require.NoError(t, err)
+
+	c, _ := out.Cell(1, 0)
+	require.Equal(t, "$12.34", c.Value)
+	c, _ = out.Cell(1, 2)
+	require.Equal(t, "10.0.0.1", c.Value)
+
+	a := []unmarshalerTypes{}
+	err = Unmarshal(out, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, items, a)
+}
+
+func TestMarshalErrors(t *testing.T) {
+	out := newSheet(t)
+
+	err := Marshal(out, "string", nil)
+	require.EqualError(t, err, "xlsx2struct: invalid marshal(non-slice of struct string)")
+
+	err = Marshal(out, []string{"a"}, nil)
+	require.EqualError(t, err, "xlsx2struct: invalid marshal(non-slice of struct []string)")
+
+	// tag errors are returned as is
+	err = Marshal(out, []struct {
+		Units int `column:"min=x"`
+	}{{}}, nil)
+	require.IsType(t, &InvalidTagError{}, err)
+
+	err = Marshal(out, []supportedTypes{{}}, nil)
+	require.Error(t, err)
+	require.IsType(t, &UnsupportedFieldError{}, err)
+}
+
+func TestExcelTimeFormat(t *testing.T) {
+	d := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, xlsx3.DefaultDateFormat, excelTimeFormat(d))
+	require.Equal(t, "yyyy-mm-dd\\Thh:mm:ss", excelTimeFormat(d, time.RFC3339))
+	require.Equal(t, "dd mmm yy", excelTimeFormat(d, "02 Jan 06"))
+	require.Equal(t, "h:mmAM/PM", excelTimeFormat(d, time.Kitchen))
+}
+
+func newSheet(t *testing.T) *xlsx3.Sheet {
+	s, err := xlsx3.NewFile().AddSheet("Sheet1")
+	require.NoError(t, err)
+	return s
+}
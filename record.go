@@ -0,0 +1,19 @@
+package xlsx2struct
+
+import (
+	"reflect"
+)
+
+// isRecordType reports whether t is a record, i.e., a map holding the values of every
+// column of a row keyed by heading. Records are read and written like a struct with a
+// single field with the rest option.
+func isRecordType(t reflect.Type) bool {
+	return isRestType(t)
+}
+
+// recordFields returns the fields of record type t.
+func recordFields(t reflect.Type) []*Field {
+	f := &Field{StructField: reflect.StructField{Name: "Record", Type: t}}
+	f.tag.rest = true
+	return []*Field{f}
+}
@@ -0,0 +1,64 @@
+package xlsx2struct
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+func TestUnmarshalRecords(t *testing.T) {
+	sheet := newSheet(t)
+	for i, h := range []string{"Name", "Score", "Active", "Joined"} {
+		c, _ := sheet.Cell(0, i)
+		c.SetString(h)
+	}
+	c, _ := sheet.Cell(1, 0)
+	c.SetString("a")
+	c, _ = sheet.Cell(1, 1)
+	c.SetFloat(9.5)
+	c, _ = sheet.Cell(1, 2)
+	c.SetBool(true)
+	c, _ = sheet.Cell(1, 3)
+	d := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	c.SetDateWithOptions(d, xlsx3.DateTimeOptions{Location: time.UTC, ExcelTimeFormat: xlsx3.DefaultDateFormat})
+	c, _ = sheet.Cell(2, 0)
+	c.SetString("b")
+
+	a := []map[string]any{}
+	err := Unmarshal(sheet, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, []map[string]any{
+		{"Name": "a", "Score": 9.5, "Active": true, "Joined": d},
+		{"Name": "b"},
+	}, a)
+
+	b := []map[string]string{}
+	err = Unmarshal(sheet, &b, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"Name": "a", "Score": "9.5", "Active": "1", "Joined": "45730"}, b[0])
+
+	for r, err := range Rows[map[string]any](sheet, nil) {
+		require.NoError(t, err)
+		require.Contains(t, r, "Name")
+	}
+}
+
+func TestMarshalRecords(t *testing.T) {
+	records := []map[string]string{{"Name": "a", "Region": "North"}, {"Name": "b", "Notes": "x"}}
+
+	out := newSheet(t)
+	err := Marshal(out, records, nil)
+	require.NoError(t, err)
+
+	for i, h := range []string{"Name", "Region", "Notes"} {
+		c, _ := out.Cell(0, i)
+		require.Equal(t, h, c.Value)
+	}
+
+	a := []map[string]string{}
+	err = Unmarshal(out, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, records, a)
+}
@@ -0,0 +1,100 @@
+package xlsx2struct
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+var (
+	restStringType = reflect.TypeFor[map[string]string]()
+	restAnyType    = reflect.TypeFor[map[string]any]()
+)
+
+// isRestType reports whether t can hold the unmapped columns of a row.
+func isRestType(t reflect.Type) bool {
+	return t == restStringType || t == restAnyType
+}
+
+// unmarshalRest reads the unmapped columns of the row into a map keyed by heading.
+// Empty cells are left out of the map. The flag is false when all cells are empty.
+func unmarshalRest(field *Field, sheet *xlsx3.Sheet, row int) (any, bool) {
+	m := reflect.MakeMap(field.Type)
+	ok := false
+
+	for _, col := range field.restColumns {
+		c, err := sheet.Cell(row, col.Index)
+		if err != nil || c.Value == "" {
+			continue
+		}
+
+		var v any = c.Value
+		if field.Type == restAnyType {
+			v = inferValue(c)
+		}
+
+		m.SetMapIndex(reflect.ValueOf(col.Heading), reflect.ValueOf(v))
+		ok = true
+	}
+
+	return m.Interface(), ok
+}
+
+// inferValue returns the value of cell typed from the cell type: float64 for numbers,
+// time.Time for numbers formatted as dates, bool for booleans and string otherwise.
+func inferValue(c *xlsx3.Cell) any {
+	switch c.Type() {
+	case xlsx3.CellTypeNumeric:
+		f, err := strconv.ParseFloat(c.Value, 64)
+		if err != nil {
+			return c.Value
+		}
+		if c.IsTime() {
+			return xlsx3.TimeFromExcelTime(f, false)
+		}
+		return f
+	case xlsx3.CellTypeBool:
+		return c.Value == "1"
+	}
+	return c.Value
+}
+
+// restHeadings returns the keys of the rest field of all structs in v, in order of first
+// appearance with the keys of each map sorted.
+func restHeadings(field *Field, v reflect.Value) []string {
+	hs := []string{}
+	seen := map[string]bool{}
+
+	for i := 0; i < v.Len(); i++ {
+		s := v.Index(i)
+		if s.Kind() == reflect.Pointer {
+			if s.IsNil() {
+				continue
+			}
+			s = s.Elem()
+		}
+
+		keys := []string{}
+		for _, k := range field.value(s).MapKeys() {
+			if !seen[k.String()] {
+				keys = append(keys, k.String())
+				seen[k.String()] = true
+			}
+		}
+		slices.Sort(keys)
+		hs = append(hs, keys...)
+	}
+
+	return hs
+}
+
+// marshalRest writes the value of key in rest field value v to the cell.
+func marshalRest(v reflect.Value, key string, cell *xlsx3.Cell) {
+	e := v.MapIndex(reflect.ValueOf(key))
+	if !e.IsValid() {
+		return
+	}
+	cell.SetValue(e.Interface())
+}
@@ -0,0 +1,80 @@
+package xlsx2struct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalRest(t *testing.T) {
+	type Struct1 struct {
+		Name  string            `column:"heading=Name"`
+		Extra map[string]string `column:",rest"`
+	}
+
+	sheet := sheetOf(t,
+		[]string{"Vendor Ref", "Name", "Notes"},
+		[]string{"V1", "a", "fragile"},
+		[]string{"V2", "b", ""},
+	)
+
+	a := []Struct1{}
+	err := Unmarshal(sheet, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Struct1{
+		{Name: "a", Extra: map[string]string{"Vendor Ref": "V1", "Notes": "fragile"}},
+		{Name: "b", Extra: map[string]string{"Vendor Ref": "V2"}},
+	}, a)
+
+	// rest consumes extra columns
+	opt := DefaultSheetOptions()
+	opt.Strict = true
+	opt.DisallowExtraColumns = true
+	err = Unmarshal(sheet, &a, opt)
+	require.NoError(t, err)
+
+	// round trip
+	out := newSheet(t)
+	err = Marshal(out, a, nil)
+	require.NoError(t, err)
+	c, _ := out.Cell(0, 1)
+	require.Equal(t, "Notes", c.Value)
+	c, _ = out.Cell(0, 2)
+	require.Equal(t, "Vendor Ref", c.Value)
+
+	b := []Struct1{}
+	err = Unmarshal(out, &b, nil)
+	require.NoError(t, err)
+	require.Equal(t, a, b)
+}
+
+func TestUnmarshalRestAny(t *testing.T) {
+	type Struct1 struct {
+		Name  string         `column:"heading=Name"`
+		Extra map[string]any `column:",rest"`
+	}
+
+	sheet := newSheet(t)
+	for i, h := range []string{"Name", "Score", "Active"} {
+		c, _ := sheet.Cell(0, i)
+		c.SetString(h)
+	}
+	c, _ := sheet.Cell(1, 0)
+	c.SetString("a")
+	c, _ = sheet.Cell(1, 1)
+	c.SetFloat(9.5)
+	c, _ = sheet.Cell(1, 2)
+	c.SetBool(true)
+
+	a := []Struct1{}
+	err := Unmarshal(sheet, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"Score": 9.5, "Active": true}, a[0].Extra)
+}
+
+func TestRestInvalidType(t *testing.T) {
+	_, err := fields(struct {
+		Extra map[string]int `column:",rest"`
+	}{})
+	require.EqualError(t, err, `xlsx2struct: invalid option "rest" for field 'Extra' (type: map[string]int, column: 'Extra')`)
+}
@@ -0,0 +1,76 @@
+package xlsx2struct
+
+import (
+	"reflect"
+	"strconv"
+
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+// unmarshalRowFields reads the fields with the rownum or cells option from the position
+// of the row (zero based).
+func unmarshalRowFields(fields map[*Field]*Column, row int) (map[*Field]any, error) {
+	m := map[*Field]any{}
+
+	for f := range fields {
+		switch {
+		case f.tag.rowNum:
+			v, _, err := unmarshalField(f, &xlsx3.Cell{Value: strconv.Itoa(row + 1)})
+			if err != nil {
+				return nil, err
+			}
+			m[f] = v
+		case f.tag.cells:
+			m[f] = cellAddresses(fields, row)
+		}
+	}
+
+	return m, nil
+}
+
+// isRowNumType reports whether a field of type t can hold the row number, read with the
+// conv option or else as a number or string.
+func isRowNumType(t reflect.Type, conv string) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if conv != "" || isUnmarshaler(t) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+
+	return false
+}
+
+// cellAddresses returns the A1 addresses of the mapped cells of the row, keyed by the
+// field heading, or by the column heading for columns of rest and group fields.
+func cellAddresses(fields map[*Field]*Column, row int) map[string]string {
+	m := map[string]string{}
+
+	address := func(c *Column) string {
+		return CellRef{Row: row + 1, Col: xlsx3.ColIndexToLetters(c.Index)}.Address()
+	}
+
+	for f, col := range fields {
+		if col != nil {
+			m[f.Heading()] = address(col)
+		}
+		for _, c := range f.restColumns {
+			m[c.Heading] = address(c)
+		}
+		if f.group != nil {
+			for _, c := range f.group.columns {
+				m[c.Heading] = address(c)
+			}
+		}
+	}
+
+	return m
+}
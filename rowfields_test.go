@@ -0,0 +1,68 @@
+package xlsx2struct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalRowFields(t *testing.T) {
+	type Struct1 struct {
+		Row    int               `column:",rownum"`
+		RowStr string            `column:",rownum"`
+		Cells  map[string]string `column:",cells"`
+		Name   string            `column:"heading=Name"`
+		Scores []int             `column:"match=^Score"`
+		Extra  map[string]string `column:",rest"`
+	}
+
+	sheet := sheetOf(t,
+		[]string{"Title"},
+		[]string{"Name", "Score 1", "Notes"},
+		[]string{"a", "1", "x"},
+		[]string{"b", "2", ""},
+	)
+
+	opt := DefaultSheetOptions()
+	opt.Row, opt.DataRow = 1, 2
+
+	a := []Struct1{}
+	err := Unmarshal(sheet, &a, opt)
+	require.NoError(t, err)
+	require.Equal(t, 3, a[0].Row)
+	require.Equal(t, "4", a[1].RowStr)
+	require.Equal(t, map[string]string{"Name": "A4", "Score 1": "B4", "Notes": "C4"}, a[1].Cells)
+
+	// metadata fields are not written
+	type Struct2 struct {
+		Row   int               `column:",rownum"`
+		Cells map[string]string `column:",cells"`
+		Name  string            `column:"heading=Name"`
+	}
+
+	out := newSheet(t)
+	err = Marshal(out, []Struct2{{Row: 3, Name: "a"}}, nil)
+	require.NoError(t, err)
+	c, _ := out.Cell(0, 0)
+	require.Equal(t, "Name", c.Value)
+}
+
+func TestRowFieldsInvalid(t *testing.T) {
+	_, err := fields(struct {
+		Cells map[string]any `column:",cells"`
+	}{})
+	require.EqualError(t, err, `xlsx2struct: invalid option "cells" for field 'Cells' (type: map[string]interface {}, column: 'Cells')`)
+
+	type Struct1 struct {
+		Row  bool   `column:",rownum"`
+		Name string `column:"heading=Name"`
+	}
+
+	err = Unmarshal(sheetOf(t, []string{"Name"}, []string{"a"}), &[]Struct1{}, nil)
+	require.EqualError(t, err, `xlsx2struct: invalid option "rownum" for field 'Row' (type: bool, column: 'Row')`)
+
+	_, err = fields(struct {
+		Row *int64 `column:",rownum"`
+	}{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,164 @@
+package xlsx2struct
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+// UnmarshalSheets reads every sheet of the file whose name matches the glob pattern, in
+// workbook order, and appends the structs of all sheets to the slice pointed to by a.
+// The pattern supports "*" for any run of characters and "?" for a single character,
+// e.g. "* 2026" for sheets "Jan 2026", "Feb 2026", etc. All sheets are read with the
+// same options, see [Unmarshal].
+//
+// A field with the sheetname option receives the name of the sheet its row comes from,
+// and with a capture index the part of the name matched by a wildcard:
+//
+//	// Field value is "Jan 2026" for rows of sheet "Jan 2026".
+//	Sheet string `column:",sheetname"`
+//
+//	// Field value is "Jan" for rows of sheet "Jan 2026" read with pattern "* 2026".
+//	Month string `column:",sheetname=1"`
+//
+// If no sheet matches, UnmarshalSheets returns a [MissingSheetsError].
+func UnmarshalSheets(file *xlsx3.File, pattern string, a any, opt *SheetOptions) error {
+	return UnmarshalSheetsRegexp(file, globPattern(pattern), a, opt)
+}
+
+// UnmarshalSheetsRegexp is like [UnmarshalSheets] but selects sheets whose name matches
+// the regular expression re. The sheetname option takes the index or name of a capture
+// group of re.
+func UnmarshalSheetsRegexp(file *xlsx3.File, re *regexp.Regexp, a any, opt *SheetOptions) error {
+	v := reflect.ValueOf(a)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return &InvalidUnmarshalError{reflect.TypeOf(a)}
+	}
+
+	if file == nil {
+		return nil
+	}
+
+	sheets := matchSheets(file, re)
+	if len(sheets) == 0 {
+		return &MissingSheetsError{Sheets: []string{re.String()}}
+	}
+
+	return unmarshalSheets(sheets, re, v.Elem(), opt)
+}
+
+// unmarshalSheets appends the structs read from the sheets to slice value v. In lenient
+// mode, the errors of all sheets are returned together in an [UnmarshalErrors], with
+// MaxErrors counted across sheets, and the rows read successfully are stored in v.
+func unmarshalSheets(sheets []*xlsx3.Sheet, re *regexp.Regexp, v reflect.Value, opt *SheetOptions) error {
+	if opt == nil {
+		opt = DefaultSheetOptions()
+	}
+
+	o := *opt
+	o.sheetPattern = re
+
+	s := reflect.MakeSlice(v.Type(), 0, 0)
+	errs := &UnmarshalErrors{}
+
+	for _, sheet := range sheets {
+		if opt.MaxErrors > 0 {
+			o.MaxErrors = opt.MaxErrors - len(errs.Errors) // errors left before the limit
+		}
+
+		var err error
+		s, err = appendStructs(s, sheet, &o)
+		if err == nil {
+			continue
+		}
+
+		se, ok := err.(*UnmarshalErrors)
+		if !ok {
+			return err
+		}
+
+		errs.Errors = append(errs.Errors, se.Errors...)
+		if errs.exceeds(opt.MaxErrors) {
+			break
+		}
+	}
+
+	v.Set(s)
+
+	if len(errs.Errors) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// matchSheets returns the sheets of the file whose name matches re, in workbook order.
+func matchSheets(file *xlsx3.File, re *regexp.Regexp) []*xlsx3.Sheet {
+	ss := []*xlsx3.Sheet{}
+	for _, s := range file.Sheets {
+		if re.MatchString(s.Name) {
+			ss = append(ss, s)
+		}
+	}
+	return ss
+}
+
+// globPattern returns the regular expression matching the glob pattern, with a capture
+// group for each wildcard.
+func globPattern(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString("(.*)")
+		case '?':
+			b.WriteString("(.)")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// unmarshalSheetFields reads the fields with the sheetname option from the name of the
+// sheet. With a capture group, the value is the part of the name captured by re.
+func unmarshalSheetFields(fields map[*Field]*Column, sheet *xlsx3.Sheet, re *regexp.Regexp) (map[*Field]any, error) {
+	m := map[*Field]any{}
+
+	for f := range fields {
+		if !f.tag.sheetName {
+			continue
+		}
+
+		name := sheet.Name
+		if c := f.tag.sheetCapture; c != "" {
+			i, err := strconv.Atoi(c)
+			if err != nil && re != nil {
+				i = re.SubexpIndex(c)
+			}
+			if re == nil || i < 0 || i > re.NumSubexp() {
+				return nil, &InvalidTagError{Field: f, Option: SourceSheetOption + "=" + c}
+			}
+
+			name = ""
+			if sm := re.FindStringSubmatch(sheet.Name); sm != nil {
+				name = sm[i]
+			}
+		}
+
+		v, _, err := unmarshalField(f, &xlsx3.Cell{Value: name})
+		if err != nil {
+			return nil, err
+		}
+		m[f] = v
+	}
+
+	return m, nil
+}
@@ -0,0 +1,117 @@
+package xlsx2struct
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+type monthlySale struct {
+	Sheet  string `column:",sheetname"`
+	Month  string `column:",sheetname=1"`
+	Region string `column:"heading=Region"`
+	Total  int    `column:"heading=Total"`
+}
+
+func monthlyWorkbook(t *testing.T) *xlsx3.File {
+	file := xlsx3.NewFile()
+	for _, name := range []string{"Jan 2026", "Summary", "Feb 2026"} {
+		s, err := file.AddSheet(name)
+		require.NoError(t, err)
+		addRows(s,
+			[]string{"Region", "Total"},
+			[]string{"North", "1"},
+		)
+	}
+	return file
+}
+
+func TestUnmarshalSheets(t *testing.T) {
+	file := monthlyWorkbook(t)
+
+	a := []monthlySale{}
+	err := UnmarshalSheets(file, "* 2026", &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, []monthlySale{
+		{Sheet: "Jan 2026", Month: "Jan", Region: "North", Total: 1},
+		{Sheet: "Feb 2026", Month: "Feb", Region: "North", Total: 1},
+	}, a)
+
+	type Struct1 struct {
+		Month string `column:",sheetname=month"`
+		Total int    `column:"heading=Total"`
+	}
+
+	b := []Struct1{}
+	err = UnmarshalSheetsRegexp(file, regexp.MustCompile(`^(?P<month>\w+) \d+$`), &b, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Struct1{{"Jan", 1}, {"Feb", 1}}, b)
+
+	// workbook pattern concatenates sheets
+	var wb struct {
+		Sales []monthlySale `sheet:"pattern=^(.*) 2026$"`
+	}
+	err = UnmarshalWorkbook(file, &wb)
+	require.NoError(t, err)
+	require.Equal(t, a, wb.Sales)
+
+	// single sheet has no capture groups
+	type Struct2 struct {
+		Sheet string `column:",sheetname"`
+		Total int    `column:"heading=Total"`
+	}
+	c := []Struct2{}
+	err = Unmarshal(file.Sheet["Summary"], &c, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Struct2{{"Summary", 1}}, c)
+}
+
+func TestUnmarshalSheetsErrors(t *testing.T) {
+	file := monthlyWorkbook(t)
+
+	err := UnmarshalSheets(file, "* 2025", &[]monthlySale{}, nil)
+	require.EqualError(t, err, "xlsx2struct: workbook is missing sheet(s) '^(.*) 2025$'")
+
+	err = Unmarshal(file.Sheet["Summary"], &[]monthlySale{}, nil)
+	require.EqualError(t, err, `xlsx2struct: invalid option "sheetname=1" for field 'Month' (type: string, column: 'Month')`)
+
+	err = UnmarshalSheets(file, "*", []monthlySale{}, nil)
+	require.IsType(t, &InvalidUnmarshalError{}, err)
+}
+
+func TestGlobPattern(t *testing.T) {
+	require.Equal(t, `^(.*) 2026 \(v(.)\)$`, globPattern("* 2026 (v?)").String())
+}
+
+func TestUnmarshalSheetsLenient(t *testing.T) {
+	file := xlsx3.NewFile()
+	for _, name := range []string{"Jan 2026", "Feb 2026", "Mar 2026"} {
+		s, err := file.AddSheet(name)
+		require.NoError(t, err)
+		addRows(s,
+			[]string{"Region", "Total"},
+			[]string{"North", "x"},
+			[]string{"South", "2"},
+		)
+	}
+
+	opt := DefaultSheetOptions()
+	opt.Lenient = true
+
+	a := []monthlySale{}
+	err := UnmarshalSheets(file, "* 2026", &a, opt)
+	var errs *UnmarshalErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs.Errors, 3)
+	require.Len(t, a, 3)
+
+	// limit counts errors of all sheets
+	opt.MaxErrors = 2
+	a = []monthlySale{}
+	err = UnmarshalSheets(file, "* 2026", &a, opt)
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs.Errors, 2)
+	require.Len(t, a, 1)
+}
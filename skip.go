@@ -0,0 +1,38 @@
+package xlsx2struct
+
+import (
+	"strings"
+
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+// RowView describes a sheet row before it is unmarshalled, see SkipRow in [SheetOptions].
+// It is the same type as [RowContext], with a zero based Row.
+type RowView = RowContext
+
+// skipRow reports whether the row is skipped by one of the skip rules of the options.
+func (opt *SheetOptions) skipRow(sheet *xlsx3.Sheet, fields map[*Field]*Column, row int) bool {
+	if opt.SkipHidden {
+		if r, err := sheet.Row(row); err == nil && r.Hidden {
+			return true
+		}
+	}
+
+	if opt.CommentPrefix != "" {
+		if c, err := sheet.Cell(row, opt.Col); err == nil && strings.HasPrefix(strings.TrimSpace(c.Value), opt.CommentPrefix) {
+			return true
+		}
+	}
+
+	if opt.SkipValue != "" {
+		if c, err := sheet.Cell(row, opt.SkipCol); err == nil && strings.TrimSpace(c.Value) == opt.SkipValue {
+			return true
+		}
+	}
+
+	if opt.SkipRow != nil {
+		return opt.SkipRow(newRowContext(sheet, fields, row))
+	}
+
+	return false
+}
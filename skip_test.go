@@ -0,0 +1,47 @@
+package xlsx2struct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipRules(t *testing.T) {
+	type Struct1 struct {
+		Region string `column:"heading=Region"`
+		Type   string `column:"heading=Type"`
+		Total  int    `column:"heading=Total"`
+	}
+
+	sheet := sheetOf(t,
+		[]string{"Region", "Type", "Total"},
+		[]string{"North", "Sale", "1"},
+		[]string{"# checked by finance", "", "x"},
+		[]string{"South", "Subtotal", "2"},
+		[]string{"East", "Refund", "3"},
+		[]string{"West", "Sale", "4"},
+	)
+	r, err := sheet.Row(4)
+	require.NoError(t, err)
+	r.Hidden = true
+
+	opt := DefaultSheetOptions()
+	opt.SkipHidden = true
+	opt.CommentPrefix = "#"
+	opt.SkipCol, opt.SkipValue = 1, "Subtotal"
+
+	a := []Struct1{}
+	err = Unmarshal(sheet, &a, opt)
+	require.NoError(t, err)
+	require.Equal(t, []Struct1{{"North", "Sale", 1}, {"West", "Sale", 4}}, a)
+
+	opt = DefaultSheetOptions()
+	opt.SkipRow = func(v RowView) bool {
+		return v.Value("Type") != "Sale" || v.Value("Missing") != ""
+	}
+
+	a = []Struct1{}
+	err = Unmarshal(sheet, &a, opt)
+	require.NoError(t, err)
+	require.Equal(t, []Struct1{{"North", "Sale", 1}, {"West", "Sale", 4}}, a)
+}
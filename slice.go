@@ -0,0 +1,121 @@
+package xlsx2struct
+
+import (
+	"encoding"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+// DefaultSeparator separates the elements of slice fields without the sep option.
+const DefaultSeparator = ","
+
+func (f Field) separator() string {
+	if f.tag.separator != "" {
+		return f.tag.separator
+	}
+	return DefaultSeparator
+}
+
+// unmarshalSlice splits v, the value of cell, with the field's separator and parses
+// each element into the element type of slice type t. Elements are trimmed when the
+// field has the trim option. An empty value unmarshals to a nil slice. A single element
+// is parsed with the type of the cell, so that a date cell reads as a time.Time.
+func unmarshalSlice(field *Field, t reflect.Type, cell *xlsx3.Cell, v string) (any, error) {
+	if v == "" {
+		return reflect.Zero(t).Interface(), nil
+	}
+
+	es := strings.Split(v, field.separator())
+	s := reflect.MakeSlice(t, 0, len(es))
+
+	ct := xlsx3.CellTypeString
+	if len(es) == 1 {
+		ct = cell.Type() // a single element can be a number or date cell
+	}
+
+	for i, e := range es {
+		if field.tag.trim {
+			e = strings.TrimSpace(e)
+		}
+
+		var a any
+		var err error
+
+		if isUnmarshaler(t.Elem()) {
+			a, err = unmarshalInterface(t.Elem(), cell, e)
+		} else {
+			a, err = parseValue(field, t.Elem(), ct, e)
+		}
+
+		if _, unsupported := err.(*UnsupportedFieldError); unsupported {
+			return nil, err
+		}
+
+		if err != nil {
+			return nil, &ElementError{Index: i, Value: e, Err: err}
+		}
+
+		s = reflect.Append(s, reflect.ValueOf(a))
+	}
+
+	return s.Interface(), nil
+}
+
+// marshalSlice writes the elements of slice v to the cell, joined with the field's separator.
+func marshalSlice(field *Field, v reflect.Value, cell *xlsx3.Cell) error {
+	if v.Len() == 0 {
+		return nil
+	}
+
+	es := make([]string, v.Len())
+	for i := range es {
+		e, err := formatElement(field, v.Index(i))
+		if err != nil {
+			return err
+		}
+		es[i] = e
+	}
+
+	cell.SetString(strings.Join(es, field.separator()))
+	return nil
+}
+
+// formatElement formats slice element e so that unmarshalSlice reads it back. Like
+// unmarshalSlice, marshalers are checked before the kind of the element.
+func formatElement(field *Field, e reflect.Value) (string, error) {
+	if t, ok := e.Interface().(time.Time); ok {
+		if len(field.tag.timeFormats) > 0 {
+			return t.Format(field.tag.timeFormats[0]), nil
+		}
+		if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+			return t.Format(time.DateOnly), nil
+		}
+		return t.Format(time.RFC3339), nil
+	}
+
+	if m, ok := e.Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+
+	switch e.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(e.Bool()), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(e.Float(), 'f', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(e.Float(), 'f', -1, 64), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(e.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(e.Uint(), 10), nil
+	case reflect.String:
+		return e.String(), nil
+	}
+
+	return "", &UnsupportedFieldError{Field: field}
+}
@@ -0,0 +1,117 @@
+package xlsx2struct
+
+import (
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sliceTypes struct {
+	Strings []string    `column:"sep=;,trim"`
+	Ints    []int       `column:"sep=;,trim,max=3"`
+	Times   []time.Time `column:"sep=|,time=02/01/2006"`
+	SKUs    []string
+	Addrs   []netip.Addr `column:"trim"`
+}
+
+func TestUnmarshalFieldSlices(t *testing.T) {
+	fields, err := fields(sliceTypes{})
+	require.NoError(t, err)
+
+	v, _, err := unmarshalField(fields["Strings"], cell("red; green ;blue"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"red", "green", "blue"}, v)
+
+	v, _, err = unmarshalField(fields["SKUs"], cell("SKU1,SKU2"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"SKU1", "SKU2"}, v)
+
+	v, _, err = unmarshalField(fields["Ints"], cell("1; 2"))
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, v)
+
+	v, _, err = unmarshalField(fields["Times"], cell("01/02/2025|02/02/2025"))
+	require.NoError(t, err)
+	require.Len(t, v, 2)
+	require.Equal(t, "2025-02-02", v.([]time.Time)[1].Format(time.DateOnly))
+
+	// date cell
+	c, err := newSheet(t).Cell(0, 0)
+	require.NoError(t, err)
+	c.SetDate(time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC))
+	v, _, err = unmarshalField(fields["Times"], c)
+	require.NoError(t, err)
+	require.Equal(t, "2026-01-02", v.([]time.Time)[0].Format(time.DateOnly))
+
+	v, _, err = unmarshalField(fields["Addrs"], cell("10.0.0.1, 10.0.0.2"))
+	require.NoError(t, err)
+	require.Equal(t, []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2")}, v)
+
+	// empty cell
+	v, ok, err := unmarshalField(fields["Strings"], cell(""))
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Nil(t, v)
+
+	// invalid element
+	_, _, err = unmarshalField(fields["Ints"], cell("1;two;3"))
+	var eerr *ElementError
+	require.ErrorAs(t, err, &eerr)
+	require.Equal(t, 1, eerr.Index)
+	require.Equal(t, "two", eerr.Value)
+	require.ErrorIs(t, err, strconv.ErrSyntax)
+	require.EqualError(t, err, "xlsx2struct: cell A1 (column 'Ints'): cannot unmarshal '1;two;3' into field 'Ints' (type: []int, column: 'Ints'): element 1 'two': invalid syntax")
+
+	// validation of length
+	_, _, err = unmarshalField(fields["Ints"], cell("1;2;3;4"))
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, "max=3", verr.Rule)
+}
+
+func TestMarshalSlices(t *testing.T) {
+	items := []sliceTypes{{
+		Strings: []string{"red", "green"},
+		Ints:    []int{1, 2},
+		Times:   []time.Time{time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		SKUs:    []string{"SKU1", "SKU2"},
+		Addrs:   []netip.Addr{netip.MustParseAddr("10.0.0.1")},
+	}}
+
+	out := newSheet(t)
+	err := Marshal(out, items, nil)
+	require.NoError(t, err)
+
+	c, _ := out.Cell(1, 0)
+	require.Equal(t, "red;green", c.Value)
+	c, _ = out.Cell(1, 2)
+	require.Equal(t, "01/02/2025", c.Value)
+
+	a := []sliceTypes{}
+	err = Unmarshal(out, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, items, a)
+}
+
+func TestMarshalSliceMarshalers(t *testing.T) {
+	type Struct1 struct {
+		Levels []level `column:"heading=Levels,sep=;"`
+	}
+
+	items := []Struct1{{Levels: []level{1, 2}}}
+
+	out := newSheet(t)
+	err := Marshal(out, items, nil)
+	require.NoError(t, err)
+
+	c, _ := out.Cell(1, 0)
+	require.Equal(t, "low;high", c.Value)
+
+	a := []Struct1{}
+	err = Unmarshal(out, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, items, a)
+}
@@ -0,0 +1,75 @@
+package xlsx2struct
+
+import (
+	"strings"
+
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+// StopRule identifies the rule that ended the rows of data of a sheet.
+type StopRule int
+
+const (
+	StopAtEmptyRows StopRule = iota // EmptyRows consecutive empty rows were found
+	StopAtMaxRow                    // the last row of the sheet was read
+	StopAtValue                     // StopValue was found in column StopCol
+	StopAtLastRow                   // LastRow was read
+)
+
+func (r StopRule) String() string {
+	switch r {
+	case StopAtEmptyRows:
+		return "empty rows"
+	case StopAtMaxRow:
+		return "max row"
+	case StopAtValue:
+		return "stop value"
+	case StopAtLastRow:
+		return "last row"
+	}
+	return "unknown"
+}
+
+// A Stop describes where and why reading the rows of a sheet ended. Like the row indexes
+// of [SheetOptions], Row is zero based: the first row after the data, e.g. the row of
+// StopValue, is row number Row + 1 in Excel.
+type Stop struct {
+	Sheet string   // sheet name
+	Rule  StopRule // rule that ended the rows of data
+	Row   int      // row index (zero based) where the data ends
+}
+
+// stopBefore reports whether the rows of data end before the given row, and the rule that ends them.
+func (opt *SheetOptions) stopBefore(sheet *xlsx3.Sheet, row int) (StopRule, bool) {
+	if opt.LastRow > 0 && row > opt.LastRow {
+		return StopAtLastRow, true
+	}
+
+	if row >= sheet.MaxRow {
+		return StopAtMaxRow, true // rows past the last row are empty
+	}
+
+	if opt.StopValue != "" {
+		if c, err := sheet.Cell(row, opt.StopCol); err == nil && strings.TrimSpace(c.Value) == opt.StopValue {
+			return StopAtValue, true
+		}
+	}
+
+	return 0, false
+}
+
+// stopAtEmpty reports whether the given number of consecutive empty rows ends the rows of data.
+// Empty rows are skipped when reading up to the last row of the sheet or to LastRow.
+func (opt *SheetOptions) stopAtEmpty(empty int) bool {
+	if opt.ReadToMaxRow || opt.LastRow > 0 {
+		return false
+	}
+	return empty >= max(opt.EmptyRows, 1)
+}
+
+// stop calls OnStop, if set, with the rule that ended the rows of data.
+func (opt *SheetOptions) stop(sheet *xlsx3.Sheet, rule StopRule, row int) {
+	if opt.OnStop != nil {
+		opt.OnStop(Stop{Sheet: sheet.Name, Rule: rule, Row: row})
+	}
+}
@@ -0,0 +1,63 @@
+package xlsx2struct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStopRules(t *testing.T) {
+	type Struct1 struct {
+		Region string `column:"heading=Region"`
+		Total  int    `column:"heading=Total"`
+	}
+
+	sheet := sheetOf(t,
+		[]string{"Region", "Total"},
+		[]string{"North", "1"},
+		[]string{},
+		[]string{"South", "2"},
+		[]string{},
+		[]string{},
+		[]string{"East", "3"},
+		[]string{"Total", "6"},
+	)
+
+	tests := []struct {
+		name    string
+		set     func(opt *SheetOptions)
+		regions []string
+		stop    Stop
+	}{
+		{"default", func(opt *SheetOptions) {}, []string{"North"}, Stop{"Sheet1", StopAtEmptyRows, 2}},
+		{"empty rows", func(opt *SheetOptions) { opt.EmptyRows = 2 }, []string{"North", "South"}, Stop{"Sheet1", StopAtEmptyRows, 4}},
+		{"max row", func(opt *SheetOptions) { opt.ReadToMaxRow = true }, []string{"North", "South", "East", "Total"}, Stop{"Sheet1", StopAtMaxRow, 8}},
+		{"stop value", func(opt *SheetOptions) { opt.ReadToMaxRow, opt.StopValue = true, "Total" }, []string{"North", "South", "East"}, Stop{"Sheet1", StopAtValue, 7}},
+		{"last row", func(opt *SheetOptions) { opt.LastRow = 3 }, []string{"North", "South"}, Stop{"Sheet1", StopAtLastRow, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stop Stop
+			opt := DefaultSheetOptions()
+			opt.OnStop = func(s Stop) { stop = s }
+			tt.set(opt)
+
+			a := []Struct1{}
+			err := Unmarshal(sheet, &a, opt)
+			require.NoError(t, err)
+
+			regions := []string{}
+			for _, s := range a {
+				regions = append(regions, s.Region)
+			}
+			require.Equal(t, tt.regions, regions)
+			require.Equal(t, tt.stop, stop)
+		})
+	}
+}
+
+func TestStopRuleString(t *testing.T) {
+	require.Equal(t, "stop value", StopAtValue.String())
+	require.Equal(t, "unknown", StopRule(-1).String())
+}
@@ -1,8 +1,54 @@
 package xlsx2struct
 
-import "reflect"
+import (
+	"reflect"
+
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+// RowContext describes the sheet row a struct was unmarshalled from, or, as a [RowView],
+// a row before it is unmarshalled. Like the row indexes of [SheetOptions], Row is zero
+// based: the row number shown in Excel, and reported by [RowError] and the rownum
+// option, is Row + 1.
+type RowContext struct {
+	Sheet *xlsx3.Sheet
+	Row   int                    // row index (zero based)
+	Cells map[string]*xlsx3.Cell // cells of mapped columns, keyed by field heading
+}
+
+// Value returns the value of the cell in the column with the given field heading, or ""
+// when the column is not mapped.
+func (ctx RowContext) Value(heading string) string {
+	if c := ctx.Cells[heading]; c != nil {
+		return c.Value
+	}
+	return ""
+}
+
+// RowValidator is implemented by structs that validate themselves after a row is unmarshalled.
+type RowValidator interface {
+	Validate() error
+}
+
+// RowUnmarshalHook is implemented by structs that need access to the source row,
+// e.g. to check rules spanning several columns or to fill derived fields.
+// AfterUnmarshalRow is called before [RowValidator.Validate].
+type RowUnmarshalHook interface {
+	AfterUnmarshalRow(ctx RowContext) error
+}
+
+var (
+	rowValidatorType     = reflect.TypeFor[RowValidator]()
+	rowUnmarshalHookType = reflect.TypeFor[RowUnmarshalHook]()
+)
 
 func newStruct(t reflect.Type, values map[*Field]any) (any, error) {
+	if isRecordType(t) {
+		for _, value := range values {
+			return value, nil // record is the value of its only field
+		}
+	}
+
 	s, ptr := getStructType(t)
 	if s == nil {
 		return nil, &InvalidUnmarshalError{Type: t}
@@ -11,13 +57,13 @@ func newStruct(t reflect.Type, values map[*Field]any) (any, error) {
 	v := reflect.New(s).Elem()
 
 	for field, value := range values {
-		f := v.FieldByName(field.Name)
+		f := field.value(v)
 		if !f.CanSet() {
 			return nil, &InvalidFieldError{Field: field}
 		}
 
 		fv := reflect.ValueOf(value)
-		if f.Type() != fv.Type() {
+		if !fv.Type().AssignableTo(f.Type()) {
 			return nil, &InvalidFieldValueError{Field: field, Value: value}
 		}
 
@@ -53,3 +99,65 @@ func getStructType(t reflect.Type) (reflect.Type, bool) {
 
 	return s, ptr
 }
+
+// hasRowHooks reports whether struct type t, or a pointer to it, implements a row hook.
+func hasRowHooks(t reflect.Type) bool {
+	s, _ := getStructType(t)
+	if s == nil {
+		return false
+	}
+
+	pt := reflect.PointerTo(s)
+	return pt.Implements(rowValidatorType) || pt.Implements(rowUnmarshalHookType)
+}
+
+func newRowContext(sheet *xlsx3.Sheet, fields map[*Field]*Column, row int) RowContext {
+	ctx := RowContext{Sheet: sheet, Row: row, Cells: map[string]*xlsx3.Cell{}}
+
+	for f, col := range fields {
+		if f.group != nil {
+			for _, gc := range f.group.columns {
+				if c, err := sheet.Cell(row, gc.Index); err == nil {
+					ctx.Cells[gc.Heading] = c
+				}
+			}
+			continue
+		}
+		if col == nil {
+			continue
+		}
+		if c, err := sheet.Cell(row, col.Index); err == nil {
+			ctx.Cells[f.Heading()] = c
+		}
+	}
+
+	return ctx
+}
+
+// afterUnmarshal calls the row hooks of item, which may modify it.
+func afterUnmarshal(item any, ctx RowContext) (any, error) {
+	v := reflect.ValueOf(item)
+	p := v
+	if v.Kind() != reflect.Pointer {
+		p = reflect.New(v.Type())
+		p.Elem().Set(v)
+	}
+
+	if h, ok := p.Interface().(RowUnmarshalHook); ok {
+		if err := h.AfterUnmarshalRow(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if r, ok := p.Interface().(RowValidator); ok {
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if v.Kind() != reflect.Pointer {
+		return p.Elem().Interface(), nil
+	}
+
+	return item, nil
+}
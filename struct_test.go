@@ -1,7 +1,10 @@
 package xlsx2struct
 
 import (
+	"errors"
+	"math"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -116,6 +119,53 @@ func TestNewStruct(t *testing.T) {
 	}
 }
 
+type orderLine struct {
+	Units int     `column:"heading=Units"`
+	Cost  float64 `column:"heading=Cost"`
+	Total float64 `column:"heading=Total"`
+	Ref   string
+}
+
+func (o orderLine) Validate() error {
+	if math.Abs(float64(o.Units)*o.Cost-o.Total) > 0.005 {
+		return errors.New("total does not equal units * cost")
+	}
+	return nil
+}
+
+func (o *orderLine) AfterUnmarshalRow(ctx RowContext) error {
+	o.Ref = ctx.Cells["Total"].Value + "@" + strconv.Itoa(ctx.Row)
+	return nil
+}
+
+func TestRowHooks(t *testing.T) {
+	sheet := sheetOf(t,
+		[]string{"Units", "Cost", "Total"},
+		[]string{"2", "1.5", "3"},
+		[]string{"2", "1.5", "4"},
+		[]string{"1", "1", "1"},
+	)
+
+	opt := DefaultSheetOptions()
+	opt.DefaultMissingColumns = true // Ref is set by the hook
+
+	a := []orderLine{}
+	err := Unmarshal(sheet, &a, opt)
+	require.EqualError(t, err, "xlsx2struct: sheet 'Sheet1', row 3: total does not equal units * cost")
+
+	var rerr *RowError
+	require.ErrorAs(t, err, &rerr)
+	require.Equal(t, 3, rerr.Row)
+
+	opt.Lenient = true
+	b := []*orderLine{}
+	err = Unmarshal(sheet, &b, opt)
+	require.ErrorAs(t, err, &rerr)
+	require.Len(t, b, 2)
+	require.Equal(t, "3@1", b[0].Ref)
+	require.Equal(t, "1@3", b[1].Ref)
+}
+
 func mapFieldValuePairs(a ...any) map[*Field]any {
 	m := map[*Field]any{}
 	for i := 0; i < len(a); i += 2 {
@@ -6,18 +6,62 @@ import (
 
 type columnTag struct {
 	heading      string
+	aliases      []string
 	trim         bool
 	timeFormats  []string
 	defaultValue string
+	prefix       string
+	converter    string
+	required     bool
+	rest         bool
+	col          string
+	index        string
+	separator    string
+	headings     string
+	match        string
+	value        bool
+	variable     bool
+	sheetName    bool
+	sheetCapture string
+	rowNum       bool
+	cells        bool
+
+	// validation options
+	min      string
+	max      string
+	length   string
+	pattern  string
+	oneOf    []string
+	notBlank bool
 }
 
 const (
 	ColumnTag = "column"
 
-	HeadingOption = "heading"
-	TrimOption    = "trim"
-	DefaultOption = "default"
-	TimeOption    = "time"
+	HeadingOption     = "heading"
+	TrimOption        = "trim"
+	DefaultOption     = "default"
+	TimeOption        = "time"
+	PrefixOption      = "prefix"
+	ConvOption        = "conv"
+	RequiredOption    = "required"
+	RestOption        = "rest"
+	ColOption         = "col"
+	IndexOption       = "index"
+	SepOption         = "sep"
+	HeadingsOption    = "headings"
+	MatchOption       = "match"
+	ValueOption       = "value"
+	VariableOption    = "variable"
+	SourceSheetOption = "sheetname"
+	RowNumOption      = "rownum"
+	CellsOption       = "cells"
+	MinOption         = "min"
+	MaxOption         = "max"
+	LenOption         = "len"
+	PatternOption     = "pattern"
+	OneOfOption       = "oneof"
+	NotBlankOption    = "notblank"
 )
 
 func parseColumnTag(str string) columnTag {
@@ -25,7 +69,7 @@ func parseColumnTag(str string) columnTag {
 	opts := strings.Split(str, ",")
 
 	for _, opt := range opts {
-		kv := strings.Split(opt, "=")
+		kv := strings.SplitN(opt, "=", 2)
 		k := strings.ToLower(strings.TrimSpace(kv[0]))
 
 		var v string
@@ -35,13 +79,56 @@ func parseColumnTag(str string) columnTag {
 
 		switch k {
 		case HeadingOption:
-			t.heading = v
+			hs := strings.Split(v, "|")
+			t.heading = hs[0]
+			t.aliases = hs[1:]
 		case TrimOption:
 			t.trim = true
 		case DefaultOption:
 			t.defaultValue = v
 		case TimeOption:
 			t.timeFormats = []string{v}
+		case PrefixOption:
+			t.prefix = v
+		case ConvOption:
+			t.converter = v
+		case RequiredOption:
+			t.required = true
+		case RestOption:
+			t.rest = true
+		case ColOption:
+			t.col = v
+		case IndexOption:
+			t.index = v
+		case SepOption:
+			t.separator = v
+		case HeadingsOption:
+			t.headings = v
+		case MatchOption:
+			t.match = v
+		case ValueOption:
+			t.value = true
+		case VariableOption:
+			t.variable = true
+		case SourceSheetOption:
+			t.sheetName = true
+			t.sheetCapture = v
+		case RowNumOption:
+			t.rowNum = true
+		case CellsOption:
+			t.cells = true
+		case MinOption:
+			t.min = v
+		case MaxOption:
+			t.max = v
+		case LenOption:
+			t.length = v
+		case PatternOption:
+			t.pattern = v
+		case OneOfOption:
+			t.oneOf = strings.Split(v, "|")
+		case NotBlankOption:
+			t.notBlank = true
 		}
 	}
 
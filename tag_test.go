@@ -13,4 +13,33 @@ func TestParseTag(t *testing.T) {
 	require.Equal(t, true, tag.trim)
 	require.Equal(t, []string{"2006-01-02"}, tag.timeFormats)
 	require.Equal(t, "None", tag.defaultValue)
+
+	tag = parseColumnTag("prefix=Ship ")
+	require.Equal(t, "Ship ", tag.prefix)
+
+	tag = parseColumnTag("heading=Order Date|Date|Fecha")
+	require.Equal(t, "Order Date", tag.heading)
+	require.Equal(t, []string{"Date", "Fecha"}, tag.aliases)
+
+	tag = parseColumnTag("heading=Price,conv=money,required")
+	require.Equal(t, "money", tag.converter)
+	require.True(t, tag.required)
+
+	tag = parseColumnTag("min=1,max=10,len=3,pattern=^[a-z]={2}$,oneof=North|South,notblank")
+	require.Equal(t, "1", tag.min)
+	require.Equal(t, "10", tag.max)
+	require.Equal(t, "3", tag.length)
+	require.Equal(t, "^[a-z]={2}$", tag.pattern)
+	require.Equal(t, []string{"North", "South"}, tag.oneOf)
+	require.True(t, tag.notBlank)
+
+	tag = parseColumnTag(",rest")
+	require.True(t, tag.rest)
+
+	tag = parseColumnTag("col=C,index=2")
+	require.Equal(t, "C", tag.col)
+	require.Equal(t, "2", tag.index)
+
+	tag = parseColumnTag("sep=;")
+	require.Equal(t, ";", tag.separator)
 }
@@ -0,0 +1,158 @@
+package xlsx2struct
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+// UnmarshalLong reads a wide sheet into the slice of struct pointed to by a, storing one
+// struct per non-empty cell of the unpivoted columns. The field with the value option
+// receives the cell, and the field with the variable option the column heading, both
+// typed like any other field. The other fields identify the row and are repeated in each
+// struct.
+//
+// For example, the row "North, 1, 2" under headings "Region, Jan, Feb" is read into
+// {North Jan 1} and {North Feb 2} by:
+//
+//	type Sales struct {
+//		Region string  `column:"heading=Region"`
+//		Month  string  `column:",variable"`
+//		Value  float64 `column:"headings=Jan..Dec,value"`
+//	}
+//
+// The value field gathers its columns with the headings or match option, or else all
+// columns not mapped to other fields. With a capture group in the match option, the
+// variable field receives the capture group instead of the heading. Empty cells are
+// skipped unless the value field has a default value.
+//
+// [Unmarshal] and [Rows] unpivot structs with a value field the same way; UnmarshalLong
+// also fails with an [InvalidUnpivotError] when the struct has no value field.
+func UnmarshalLong(sheet *xlsx3.Sheet, a any, opt *SheetOptions) error {
+	v := reflect.ValueOf(a)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return &InvalidUnmarshalError{reflect.TypeOf(a)}
+	}
+
+	t := v.Elem().Type().Elem()
+	fields, err := extractFields(t)
+	if err != nil {
+		return err
+	}
+
+	if !slices.ContainsFunc(fields, func(f *Field) bool { return f.tag.value }) {
+		return &InvalidUnpivotError{Type: t}
+	}
+
+	return Unmarshal(sheet, a, opt)
+}
+
+// unpivotCell holds the field values read from a cell of an unpivoted column.
+type unpivotCell struct {
+	variable any // value of the variable field, read from the column heading
+	value    any // value of the value field, read from the cell
+}
+
+// linkUnpivotFields checks that a struct has at most one value and one variable field,
+// and links the variable field to the value field.
+func linkUnpivotFields(fields []*Field) error {
+	var value, variable *Field
+
+	for _, f := range fields {
+		if f.tag.value {
+			if value != nil {
+				return &InvalidTagError{Field: f, Option: ValueOption}
+			}
+			value = f
+		}
+		if f.tag.variable {
+			if variable != nil || f.tag.value || f.tag.rest || f.group != nil {
+				return &InvalidTagError{Field: f, Option: VariableOption}
+			}
+			variable = f
+		}
+	}
+
+	if variable != nil && value == nil {
+		return &InvalidTagError{Field: variable, Option: VariableOption}
+	}
+
+	if value != nil {
+		value.group.variable = variable
+	}
+
+	return nil
+}
+
+// unmarshalValues reads the unpivoted columns of the row for the value field. The flag
+// is false when all cells are empty.
+func unmarshalValues(field *Field, sheet *xlsx3.Sheet, row int) (any, bool, error) {
+	g := field.group
+	cells := make([]unpivotCell, 0, len(g.columns))
+	allOk := false
+	errs := []error{}
+
+	for _, col := range g.columns {
+		c, err := sheet.Cell(row, col.Index)
+		if err != nil {
+			c = &xlsx3.Cell{}
+		}
+
+		if c.Value == "" && field.tag.defaultValue == "" {
+			continue // empty cell
+		}
+
+		a, ok, err := unmarshalField(g.elemField(col.Heading), c)
+		allOk = allOk || ok
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		u := unpivotCell{value: a}
+		if g.variable != nil {
+			u.variable, _, err = unmarshalField(g.variable, &xlsx3.Cell{Value: g.key(col.Heading)})
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		cells = append(cells, u)
+	}
+
+	if len(errs) > 0 {
+		return nil, allOk, errors.Join(errs...)
+	}
+
+	return cells, allOk, nil
+}
+
+// unpivot returns the field values of each struct read from a row: one per cell of the
+// value field, or the row values themselves when the struct has no value field.
+func unpivot(values map[*Field]any) []map[*Field]any {
+	for f, v := range values {
+		if !f.tag.value {
+			continue
+		}
+
+		cells := v.([]unpivotCell)
+		ms := make([]map[*Field]any, 0, len(cells))
+
+		for _, c := range cells {
+			m := maps.Clone(values)
+			m[f] = c.value
+			if f.group.variable != nil {
+				m[f.group.variable] = c.variable
+			}
+			ms = append(ms, m)
+		}
+
+		return ms
+	}
+
+	return []map[*Field]any{values}
+}
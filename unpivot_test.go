@@ -0,0 +1,118 @@
+package xlsx2struct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalLong(t *testing.T) {
+	type Sales struct {
+		Region string  `column:"heading=Region"`
+		Month  string  `column:",variable"`
+		Value  float64 `column:"headings=Jan..Mar,value"`
+	}
+
+	sheet := sheetOf(t,
+		[]string{"Region", "Jan", "Feb", "Mar", "Total"},
+		[]string{"North", "1", "", "3", "4"},
+		[]string{"South", "2", "2", "2", "6"},
+	)
+
+	a := []Sales{}
+	err := UnmarshalLong(sheet, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Sales{
+		{"North", "Jan", 1}, {"North", "Mar", 3},
+		{"South", "Jan", 2}, {"South", "Feb", 2}, {"South", "Mar", 2},
+	}, a)
+
+	// empty cells are not validated
+	type Sales3 struct {
+		Region string  `column:"heading=Region"`
+		Month  string  `column:",variable"`
+		Value  float64 `column:"headings=Jan..Mar,value,min=1"`
+	}
+
+	c := []Sales3{}
+	err = UnmarshalLong(sheet, &c, nil)
+	require.NoError(t, err)
+	require.Len(t, c, 5)
+
+	// default value keeps empty cells
+	type Sales2 struct {
+		Region string  `column:"heading=Region"`
+		Month  string  `column:",variable"`
+		Value  float64 `column:"headings=Jan..Mar,value,default=0"`
+	}
+
+	b := []Sales2{}
+	err = UnmarshalLong(sheet, &b, nil)
+	require.NoError(t, err)
+	require.Len(t, b, 6)
+	require.Equal(t, Sales2{"North", "Feb", 0}, b[1])
+}
+
+func TestUnmarshalLongVariable(t *testing.T) {
+	// typed variable from the capture group
+	type Score struct {
+		Name  string `column:"heading=Name"`
+		Round int    `column:",variable"`
+		Score int    `column:"match=^Round (\\d+)$,value"`
+	}
+
+	// value gathers unmapped columns
+	type Other struct {
+		Name  string `column:"heading=Name"`
+		Key   string `column:",variable"`
+		Value string `column:",value"`
+	}
+
+	sheet := sheetOf(t,
+		[]string{"Name", "Round 1", "Round 2"},
+		[]string{"a", "7", "9"},
+	)
+
+	a := []Score{}
+	err := UnmarshalLong(sheet, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Score{{"a", 1, 7}, {"a", 2, 9}}, a)
+
+	b := []Other{}
+	err = UnmarshalLong(sheet, &b, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Other{{"a", "Round 1", "7"}, {"a", "Round 2", "9"}}, b)
+}
+
+func TestUnmarshalLongErrors(t *testing.T) {
+	type Struct1 struct {
+		Name string `column:"heading=Name"`
+	}
+
+	sheet := sheetOf(t,
+		[]string{"Name", "Jan"},
+		[]string{"a", "x"},
+	)
+
+	err := UnmarshalLong(sheet, &[]Struct1{}, nil)
+	require.EqualError(t, err, "xlsx2struct: invalid unpivot(no value field in xlsx2struct.Struct1)")
+
+	type Struct2 struct {
+		Name  string `column:"heading=Name"`
+		Value int    `column:",value"`
+	}
+
+	err = UnmarshalLong(sheet, &[]Struct2{}, nil)
+	require.EqualError(t, err, `xlsx2struct: sheet 'Sheet1', cell B2 (column 'Jan'): cannot unmarshal 'x' into field 'Value' (type: int, column: 'Jan'): invalid syntax`)
+
+	_, err = fields(struct {
+		Month string `column:",variable"`
+	}{})
+	require.EqualError(t, err, `xlsx2struct: invalid option "variable" for field 'Month' (type: string, column: 'Month')`)
+
+	_, err = fields(struct {
+		A int `column:",value"`
+		B int `column:",value"`
+	}{})
+	require.EqualError(t, err, `xlsx2struct: invalid option "value" for field 'B' (type: int, column: 'B')`)
+}
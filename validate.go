@@ -0,0 +1,163 @@
+package xlsx2struct
+
+import (
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// A rule is a validation option of a field, checked after the cell is unmarshalled.
+type rule struct {
+	name  string // option as written in the tag, e.g. "min=1"
+	check func(v reflect.Value, s string) bool
+}
+
+// compileRules builds the validation rules of the field from its tag options.
+func compileRules(f *Field) error {
+	t := f.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var rules []rule
+
+	if f.tag.min != "" {
+		r, err := boundRule(f, t, MinOption, f.tag.min, -1)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, r)
+	}
+
+	if f.tag.max != "" {
+		r, err := boundRule(f, t, MaxOption, f.tag.max, 1)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, r)
+	}
+
+	if f.tag.length != "" {
+		n, err := strconv.Atoi(f.tag.length)
+		if err != nil || (t.Kind() != reflect.String && t.Kind() != reflect.Slice) {
+			return &InvalidTagError{Field: f, Option: LenOption + "=" + f.tag.length}
+		}
+		rules = append(rules, rule{LenOption + "=" + f.tag.length, func(v reflect.Value, s string) bool {
+			return !v.IsValid() || length(v) == n
+		}})
+	}
+
+	if f.tag.pattern != "" {
+		re, err := regexp.Compile(f.tag.pattern)
+		if err != nil {
+			return &InvalidTagError{Field: f, Option: PatternOption + "=" + f.tag.pattern, Err: err}
+		}
+		rules = append(rules, rule{PatternOption + "=" + f.tag.pattern, func(v reflect.Value, s string) bool {
+			return !v.IsValid() || re.MatchString(s)
+		}})
+	}
+
+	if len(f.tag.oneOf) > 0 {
+		rules = append(rules, rule{OneOfOption + "=" + strings.Join(f.tag.oneOf, "|"), func(v reflect.Value, s string) bool {
+			return !v.IsValid() || slices.Contains(f.tag.oneOf, s)
+		}})
+	}
+
+	if f.tag.notBlank {
+		rules = append(rules, rule{NotBlankOption, func(v reflect.Value, s string) bool {
+			return strings.TrimSpace(s) != ""
+		}})
+	}
+
+	f.rules = rules
+
+	return nil
+}
+
+// boundRule returns a min (sign -1) or max (sign 1) rule. Numbers are compared by value,
+// strings and slices by length and times by instant.
+func boundRule(f *Field, t reflect.Type, opt, bound string, sign int) (rule, error) {
+	name := opt + "=" + bound
+	invalid := &InvalidTagError{Field: f, Option: name}
+
+	var cmp func(v reflect.Value) int
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b, err := strconv.ParseFloat(bound, 64)
+		if err != nil {
+			return rule{}, invalid
+		}
+		cmp = func(v reflect.Value) int { return compare(float64(v.Int()), b) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		b, err := strconv.ParseFloat(bound, 64)
+		if err != nil {
+			return rule{}, invalid
+		}
+		cmp = func(v reflect.Value) int { return compare(float64(v.Uint()), b) }
+	case reflect.Float32, reflect.Float64:
+		b, err := strconv.ParseFloat(bound, 64)
+		if err != nil {
+			return rule{}, invalid
+		}
+		cmp = func(v reflect.Value) int { return compare(v.Float(), b) }
+	case reflect.String, reflect.Slice:
+		b, err := strconv.Atoi(bound)
+		if err != nil {
+			return rule{}, invalid
+		}
+		cmp = func(v reflect.Value) int { return compare(length(v), b) }
+	default:
+		if t != reflect.TypeOf(time.Time{}) {
+			return rule{}, invalid
+		}
+		b, err := parseTime(bound, f.tag.timeFormats...)
+		if err != nil {
+			return rule{}, invalid
+		}
+		cmp = func(v reflect.Value) int { return v.Interface().(time.Time).Compare(b) }
+	}
+
+	return rule{name, func(v reflect.Value, s string) bool {
+		return !v.IsValid() || cmp(v)*sign <= 0
+	}}, nil
+}
+
+// length returns the number of characters of a string or elements of a slice.
+func length(v reflect.Value) int {
+	if v.Kind() == reflect.String {
+		return utf8.RuneCountInString(v.String())
+	}
+	return v.Len()
+}
+
+func compare[T int | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// validate checks value a, unmarshalled from cell text s, against the field's rules and
+// returns the name of the first rule violated. A nil a is only checked for blank values.
+func validate(field *Field, a any, s string) (string, bool) {
+	var v reflect.Value
+	if a != nil {
+		v = reflect.ValueOf(a)
+	}
+
+	for _, r := range field.rules {
+		if !r.check(v, s) {
+			return r.name, false
+		}
+	}
+
+	return "", true
+}
@@ -0,0 +1,139 @@
+package xlsx2struct
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type validated struct {
+	Units  int       `column:"min=1,max=100"`
+	Cost   float64   `column:"min=0.5"`
+	Code   string    `column:"len=3,pattern=^[A-Z]+$"`
+	Name   string    `column:"min=2,max=4,trim"`
+	Region string    `column:"oneof=North|South|East"`
+	Rep    *string   `column:"notblank"`
+	Date   time.Time `column:"min=2025-01-01"`
+}
+
+func TestValidate(t *testing.T) {
+	fields, err := fields(validated{})
+	require.NoError(t, err)
+
+	type test struct {
+		Field string
+		Value string
+		Rule  string
+	}
+
+	tests := []*test{
+		{Field: "Units", Value: "1"},
+		{Field: "Units", Value: "100"},
+		{Field: "Units", Value: "0", Rule: "min=1"},
+		{Field: "Units", Value: "101", Rule: "max=100"},
+		{Field: "Cost", Value: "0.5"},
+		{Field: "Cost", Value: "0.49", Rule: "min=0.5"},
+		{Field: "Code", Value: "ABC"},
+		{Field: "Code", Value: "ABCD", Rule: "len=3"},
+		{Field: "Code", Value: "AB1", Rule: "pattern=^[A-Z]+$"},
+		{Field: "Name", Value: " Bob "},
+		{Field: "Name", Value: "B", Rule: "min=2"},
+		{Field: "Name", Value: "Bobby", Rule: "max=4"},
+		{Field: "Region", Value: "East"},
+		{Field: "Region", Value: "West", Rule: "oneof=North|South|East"},
+		{Field: "Rep", Value: "Jones"},
+		{Field: "Rep", Value: "", Rule: "notblank"},
+		{Field: "Rep", Value: "  ", Rule: "notblank"},
+		{Field: "Date", Value: "2025-01-01"},
+		{Field: "Date", Value: "2024-12-31", Rule: "min=2025-01-01"},
+	}
+
+	for _, test := range tests {
+		_, _, err := unmarshalField(fields[test.Field], cell(test.Value))
+		if test.Rule == "" {
+			require.NoError(t, err, test.Value)
+			continue
+		}
+
+		var verr *ValidationError
+		require.ErrorAs(t, err, &verr, test.Value)
+		require.Equal(t, test.Rule, verr.Rule)
+		require.Equal(t, test.Field, verr.Field.Name)
+	}
+}
+
+func TestValidateInvalidTag(t *testing.T) {
+	type test struct {
+		Any   any
+		Error string
+	}
+
+	tests := []*test{
+		{Any: struct {
+			A int `column:"min=one"`
+		}{}, Error: `xlsx2struct: invalid option "min=one" for field 'A' (type: int, column: 'A')`},
+		{Any: struct {
+			A int `column:"len=3"`
+		}{}, Error: `xlsx2struct: invalid option "len=3" for field 'A' (type: int, column: 'A')`},
+		{Any: struct {
+			A string `column:"pattern=["`
+		}{}, Error: "xlsx2struct: invalid option \"pattern=[\" for field 'A' (type: string, column: 'A'): error parsing regexp: missing closing ]: `[`"},
+		{Any: struct {
+			A bool `column:"max=1"`
+		}{}, Error: `xlsx2struct: invalid option "max=1" for field 'A' (type: bool, column: 'A')`},
+	}
+
+	for _, test := range tests {
+		_, err := fields(test.Any)
+		require.EqualError(t, err, test.Error)
+	}
+}
+
+func TestUnmarshalValidation(t *testing.T) {
+	sheet := sheetOf(t,
+		[]string{"Units", "Region"},
+		[]string{"10", "East"},
+		[]string{"0", "West"},
+	)
+
+	type Struct1 struct {
+		Units  int    `column:"heading=Units,min=1"`
+		Region string `column:"heading=Region,oneof=North|South|East"`
+	}
+
+	opt := DefaultSheetOptions()
+	opt.Lenient = true
+
+	a := []Struct1{}
+	err := Unmarshal(sheet, &a, opt)
+	require.EqualError(t, err, "xlsx2struct: 2 error(s) unmarshalling sheet\n"+
+		"\txlsx2struct: sheet 'Sheet1', cell A3 (column 'Units'): value '0' of field 'Units' (type: int, column: 'Units') violates rule min=1\n"+
+		"\txlsx2struct: sheet 'Sheet1', cell B3 (column 'Region'): value 'West' of field 'Region' (type: string, column: 'Region') violates rule oneof=North|South|East")
+	require.Equal(t, []Struct1{{10, "East"}}, a)
+}
+
+func TestValidationSkipsEmptyRows(t *testing.T) {
+	type Struct1 struct {
+		Name  string `column:"heading=Name,notblank"`
+		Units int    `column:"heading=Units,min=1"`
+	}
+
+	sheet := sheetOf(t,
+		[]string{"Name", "Units"},
+		[]string{"a", "1"},
+		[]string{},
+		[]string{"b", "2"},
+	)
+
+	a := []Struct1{}
+	err := Unmarshal(sheet, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Struct1{{"a", 1}}, a)
+
+	opt := DefaultSheetOptions()
+	opt.EmptyRows = 2
+	err = Unmarshal(sheet, &a, opt)
+	require.NoError(t, err)
+	require.Equal(t, []Struct1{{"a", 1}, {"b", 2}}, a)
+}
@@ -0,0 +1,254 @@
+package xlsx2struct
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+const (
+	SheetTag = "sheet"
+
+	SheetNameOption       = "name"
+	SheetIndexOption      = "index"
+	SheetPatternOption    = "pattern"
+	SheetOptionalOption   = "optional"
+	SheetRowOption        = "row"
+	SheetColOption        = "col"
+	SheetDataRowOption    = "datarow"
+	SheetDetectOption     = "detect"
+	SheetLenientOption    = "lenient"
+	SheetStrictOption     = "strict"
+	SheetNoHeadingsOption = "noheadings"
+)
+
+type sheetTag struct {
+	name     string
+	index    int // -1 when not set
+	pattern  *regexp.Regexp
+	optional bool
+	opt      *SheetOptions
+}
+
+// parseSheetTag parses the sheet tag of field f, with the options of the tag overriding
+// a copy of opt. Without a name, index or pattern, the sheet is named after the field.
+// With a row or noheadings option but no datarow option, data starts on the row after
+// the headings, or on the row itself for a sheet without headings.
+func parseSheetTag(f reflect.StructField, str string, opt *SheetOptions) (*sheetTag, error) {
+	if opt == nil {
+		opt = DefaultSheetOptions()
+	}
+
+	o := *opt
+	t := &sheetTag{index: -1, opt: &o}
+	dataRow, headingRow := false, false
+
+	for _, option := range strings.Split(str, ",") {
+		kv := strings.SplitN(option, "=", 2)
+		k := strings.ToLower(strings.TrimSpace(kv[0]))
+
+		var v string
+		if len(kv) > 1 {
+			v = kv[1]
+		}
+
+		var err error
+		var n int
+
+		switch k {
+		case SheetIndexOption, SheetRowOption, SheetColOption, SheetDataRowOption, SheetDetectOption:
+			n, err = strconv.Atoi(v)
+			if err == nil && n < 0 {
+				err = strconv.ErrRange
+			}
+		case SheetPatternOption:
+			t.pattern, err = regexp.Compile(v)
+		}
+
+		if err != nil {
+			return nil, &InvalidSheetFieldError{Field: f.Name, Type: f.Type, Option: option, Err: err}
+		}
+
+		switch k {
+		case SheetNameOption:
+			t.name = v
+		case SheetIndexOption:
+			t.index = n
+		case SheetOptionalOption:
+			t.optional = true
+		case SheetRowOption:
+			t.opt.Row = n
+			headingRow = true
+		case SheetColOption:
+			t.opt.Col = n
+		case SheetDataRowOption:
+			t.opt.DataRow = n
+			dataRow = true
+		case SheetDetectOption:
+			t.opt.DetectRows = n
+		case SheetLenientOption:
+			t.opt.Lenient = true
+		case SheetStrictOption:
+			t.opt.Strict = true
+		case SheetNoHeadingsOption:
+			t.opt.NoHeadings = true
+			headingRow = true
+		}
+	}
+
+	if t.name == "" && t.index < 0 && t.pattern == nil {
+		t.name = f.Name
+	}
+
+	if headingRow && !dataRow {
+		t.opt.DataRow = t.opt.Row + 1
+		if t.opt.NoHeadings {
+			t.opt.DataRow = t.opt.Row
+		}
+	}
+
+	return t, nil
+}
+
+// String describes the sheet selected by the tag.
+func (t *sheetTag) String() string {
+	switch {
+	case t.name != "":
+		return t.name
+	case t.pattern != nil:
+		return "/" + t.pattern.String() + "/"
+	}
+	return "#" + strconv.Itoa(t.index)
+}
+
+// find returns the sheets of the file selected by the tag. A pattern selects all
+// matching sheets in workbook order.
+func (t *sheetTag) find(file *xlsx3.File) []*xlsx3.Sheet {
+	switch {
+	case t.name != "":
+		if s := file.Sheet[t.name]; s != nil {
+			return []*xlsx3.Sheet{s}
+		}
+		return nil
+	case t.pattern != nil:
+		return matchSheets(file, t.pattern)
+	}
+
+	if t.index < len(file.Sheets) {
+		return []*xlsx3.Sheet{file.Sheets[t.index]}
+	}
+	return nil
+}
+
+// UnmarshalWorkbook reads the sheets of the file into the fields of the struct pointed
+// to by a. Each field with a sheet tag must be a slice, and is read from its sheet as
+// by [Unmarshal]. If a is nil or not a pointer to a struct, UnmarshalWorkbook returns an
+// [InvalidUnmarshalError].
+//
+// Examples of struct field tags and their meanings:
+//
+//	// Field values come from sheet "Sales Orders".
+//	Orders []Order `sheet:"name=Sales Orders"`
+//
+//	// Field values come from the first sheet, headings are on row 3 (zero based 2)
+//	// and data starts on row 4.
+//	Reps []Rep `sheet:"index=0,row=2"`
+//
+//	// Field values come from all sheets whose name matches the pattern, if any,
+//	// concatenated in workbook order as by [UnmarshalSheetsRegexp].
+//	Notes []Note `sheet:"pattern=^Notes,optional"`
+//
+// Other options set the [SheetOptions] of the sheet: col, datarow, detect (DetectRows),
+// lenient, strict and noheadings. Other sheet options, such as Converters or SkipRow,
+// are given with [UnmarshalWorkbookOptions]. Sheets missing from the file, unless optional, are
+// reported together in a [MissingSheetsError] before any sheet is read. The errors of
+// lenient sheets are returned together in an [UnmarshalErrors] once all sheets have been
+// read, with each sheet's MaxErrors applied to its own field.
+func UnmarshalWorkbook(file *xlsx3.File, a any) error {
+	return UnmarshalWorkbookOptions(file, a, nil)
+}
+
+// UnmarshalWorkbookOptions is like [UnmarshalWorkbook] but reads the sheet of each field
+// with the options in opts under the field name, or [DefaultSheetOptions] when there are
+// none. The options of the sheet tag override those options. For example:
+//
+//	opts := map[string]*SheetOptions{
+//		"Orders": {DataRow: 1, Converters: map[string]Converter{"cents": cents}},
+//	}
+func UnmarshalWorkbookOptions(file *xlsx3.File, a any, opts map[string]*SheetOptions) error {
+	v := reflect.ValueOf(a)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return &InvalidUnmarshalError{reflect.TypeOf(a)}
+	}
+
+	if file == nil {
+		return nil
+	}
+
+	v = v.Elem()
+	s := v.Type()
+
+	type sheetField struct {
+		index  int
+		sheets []*xlsx3.Sheet
+		tag    *sheetTag
+	}
+
+	fs := []sheetField{}
+	missing := []string{}
+
+	for i := 0; i < s.NumField(); i++ {
+		f := s.Field(i)
+		str, ok := f.Tag.Lookup(SheetTag)
+		if !ok {
+			continue
+		}
+
+		if !f.IsExported() || f.Type.Kind() != reflect.Slice {
+			return &InvalidSheetFieldError{Field: f.Name, Type: f.Type}
+		}
+
+		t, err := parseSheetTag(f, str, opts[f.Name])
+		if err != nil {
+			return err
+		}
+
+		sheets := t.find(file)
+		if len(sheets) == 0 {
+			if !t.optional {
+				missing = append(missing, t.String())
+			}
+			continue
+		}
+
+		fs = append(fs, sheetField{i, sheets, t})
+	}
+
+	if len(missing) > 0 {
+		return &MissingSheetsError{Sheets: missing}
+	}
+
+	errs := &UnmarshalErrors{}
+
+	for _, f := range fs {
+		err := unmarshalSheets(f.sheets, f.tag.pattern, v.Field(f.index), f.tag.opt)
+		if err == nil {
+			continue
+		}
+
+		se, ok := err.(*UnmarshalErrors)
+		if !ok {
+			return err
+		}
+		errs.Errors = append(errs.Errors, se.Errors...) // keep reading the other sheets
+	}
+
+	if len(errs.Errors) > 0 {
+		return errs
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package xlsx2struct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	xlsx3 "github.com/tealeg/xlsx/v3"
+)
+
+type rep struct {
+	Name   string `column:"heading=Rep"`
+	Region string `column:"heading=Region"`
+}
+
+func TestUnmarshalWorkbook(t *testing.T) {
+	file, err := xlsx3.OpenFile("testdata/salesorders.xlsx")
+	require.NoError(t, err)
+
+	reps, err := file.AddSheet("Reps")
+	require.NoError(t, err)
+	addRows(reps,
+		[]string{"Sales reps"},
+		[]string{},
+		[]string{"Rep", "Region"},
+		[]string{"Jones", "East"},
+	)
+
+	var wb struct {
+		Orders []*SaleOrder `sheet:"name=Sales Orders"`
+		Reps   []rep        `sheet:"pattern=^Re,row=2"`
+		First  []SaleOrder  `sheet:"index=0"`
+		Notes  []rep        `sheet:"name=Notes,optional"`
+		Ignore []rep
+	}
+
+	err = UnmarshalWorkbook(file, &wb)
+	require.NoError(t, err)
+	require.Len(t, wb.Orders, 20)
+	require.Len(t, wb.First, 20)
+	require.Equal(t, []rep{{Name: "Jones", Region: "East"}}, wb.Reps)
+	require.Nil(t, wb.Notes)
+	require.Nil(t, wb.Ignore)
+}
+
+func TestUnmarshalWorkbookLenient(t *testing.T) {
+	file := xlsx3.NewFile()
+	sheets := map[string][][]string{
+		"A": {{"Rep", "Region"}, {"Jones", "East"}},
+		"B": {{"Name", "Units", "Cost"}, {"a", "ten", "1.5"}, {"b", "2", "2.5"}},
+		"C": {{"Rep", "Region"}, {"Smith", "West"}},
+	}
+	for _, name := range []string{"A", "B", "C"} {
+		s, err := file.AddSheet(name)
+		require.NoError(t, err)
+		addRows(s, sheets[name]...)
+	}
+
+	type item struct {
+		Name  string  `column:"heading=Name"`
+		Units int     `column:"heading=Units"`
+		Cost  float64 `column:"heading=Cost"`
+	}
+
+	var wb struct {
+		A []rep  `sheet:"name=A"`
+		B []item `sheet:"name=B,lenient"`
+		C []rep  `sheet:"name=C"`
+	}
+
+	// later sheets are read after lenient errors
+	err := UnmarshalWorkbook(file, &wb)
+	var errs *UnmarshalErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs.Errors, 1)
+	require.Equal(t, []item{{"b", 2, 2.5}}, wb.B)
+	require.Equal(t, []rep{{Name: "Smith", Region: "West"}}, wb.C)
+}
+
+func TestUnmarshalWorkbookOptions(t *testing.T) {
+	file := xlsx3.NewFile()
+	s, err := file.AddSheet("Items")
+	require.NoError(t, err)
+	addRows(s,
+		[]string{"Price list"},
+		[]string{"NAME", "PRICE"},
+		[]string{"Pencil", "$1.99"},
+		[]string{"# discontinued", "$9.99"},
+		[]string{"Pen", "$2.50"},
+	)
+
+	type item struct {
+		Name  string `column:"heading=Name"`
+		Price int64  `column:"heading=Price,conv=cents"`
+	}
+
+	var wb struct {
+		Items []item `sheet:"name=Items,row=1"`
+	}
+
+	opts := map[string]*SheetOptions{
+		"Items": {
+			HeadingMatch:  MatchFoldCase,
+			Converters:    map[string]Converter{"cents": centsConverter},
+			CommentPrefix: "#",
+		},
+	}
+
+	// tag options override the field options
+	err = UnmarshalWorkbookOptions(file, &wb, opts)
+	require.NoError(t, err)
+	require.Equal(t, []item{{"Pencil", 199}, {"Pen", 250}}, wb.Items)
+	require.Equal(t, 0, opts["Items"].Row)
+}
+
+func TestUnmarshalWorkbookErrors(t *testing.T) {
+	file := xlsx3.NewFile()
+	_, err := file.AddSheet("Sheet1")
+	require.NoError(t, err)
+
+	var missing struct {
+		Orders []SaleOrder `sheet:"name=Sales Orders"`
+		Reps   []rep       `sheet:"index=3"`
+		Ok     []rep       `sheet:"index=0"`
+	}
+	err = UnmarshalWorkbook(file, &missing)
+	require.EqualError(t, err, "xlsx2struct: workbook is missing sheet(s) 'Sales Orders', '#3'")
+
+	var invalid struct {
+		Reps []rep `sheet:"index=0,row=x"`
+	}
+	err = UnmarshalWorkbook(file, &invalid)
+	require.EqualError(t, err, `xlsx2struct: invalid sheet option "row=x" for field 'Reps': strconv.Atoi: parsing "x": invalid syntax`)
+
+	var notSlice struct {
+		Rep rep `sheet:"index=0"`
+	}
+	err = UnmarshalWorkbook(file, &notSlice)
+	require.EqualError(t, err, "xlsx2struct: invalid sheet field 'Rep' (type: xlsx2struct.rep)")
+
+	err = UnmarshalWorkbook(file, missing)
+	require.IsType(t, &InvalidUnmarshalError{}, err)
+}
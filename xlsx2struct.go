@@ -2,7 +2,12 @@
 package xlsx2struct
 
 import (
+	"errors"
+	"iter"
+	"maps"
 	"reflect"
+	"regexp"
+	"slices"
 	"strings"
 
 	xlsx3 "github.com/tealeg/xlsx/v3"
@@ -20,10 +25,67 @@ import (
 //
 // The instance "opts" specifies that the first heading is located at cell "A1",
 // row "2" contains the first row of data, and cell "A2" is the first data cell.
+//
+// With a HeadingDepth greater than one, headings of the heading rows are joined with the
+// HeadingSeparator, so the column "Revenue" under a merged "Q1" cell has heading "Q1/Revenue".
+//
+// When DetectRows is set, the heading row is found by scanning the first rows for the
+// block of cells that matches the most field headings. Row and Col are then ignored,
+// and the first row of data keeps its offset (DataRow - Row) from the heading row.
+//
+// Reading fails upfront with a [MissingColumnsError] listing the fields whose column is
+// missing from the sheet. With DefaultMissingColumns, such fields get their default
+// value instead, unless they have the required option or Strict is set.
+//
+// By default, the data ends at the first empty row. With EmptyRows, shorter runs of empty
+// rows are skipped. ReadToMaxRow and LastRow skip all empty rows and read up to the last
+// row of the sheet or to LastRow, and StopValue ends the data at a footer row, e.g. "Total".
+// The data always ends at the last row of the sheet. OnStop is told which rule ended it.
+//
+// Rows matching a skip rule, such as SkipHidden or SkipRow, are left out before their
+// cells are read. Skipped rows do not end the data.
+//
+// By default, reading stops at the first cell that cannot be unmarshalled. When Lenient
+// is set, rows with invalid cells are skipped and all errors are returned together in
+// an [UnmarshalErrors] once the sheet has been read, or as soon as MaxErrors is reached.
 type SheetOptions struct {
 	Row     int // row index (zero based) of the first heading
 	Col     int // column index (zero based) of the first heading
 	DataRow int // row index (zero based) of the first row of data
+
+	Lenient   bool // skip rows with invalid cells and collect errors
+	MaxErrors int  // number of errors returned by a lenient read before it stops (zero means no limit)
+
+	Converters map[string]Converter // converters used in place of registered converters of the same name
+
+	HeadingMatch     HeadingMatch // how column headings are matched to field headings
+	HeadingDepth     int          // number of heading rows, e.g. 2 for "Q1" above "Revenue"
+	HeadingSeparator string       // joins grouped headings, DefaultHeadingSeparator when empty
+
+	Strict                bool // treat every field as required, see the required option
+	DefaultMissingColumns bool // fields whose column is missing get their default value
+	DisallowExtraColumns  bool // fail when a column is not mapped to any field
+
+	NoHeadings bool // sheet has no heading row, fields are mapped with the col or index option only
+
+	DetectRows int             // number of rows scanned for the heading row (zero disables detection)
+	DetectCols int             // number of columns scanned for the first heading (zero means all)
+	OnDetect   func(Detection) // called with the position of the detected heading row
+
+	EmptyRows    int        // number of consecutive empty rows that end the data (one when zero)
+	ReadToMaxRow bool       // read up to the last row of the sheet, skipping empty rows
+	StopValue    string     // value in column StopCol that ends the data, e.g. "Total"
+	StopCol      int        // column index (zero based) checked for StopValue
+	LastRow      int        // row index (zero based) of the last row of data (zero means no limit)
+	OnStop       func(Stop) // called with the rule that ended the data
+
+	SkipHidden    bool               // skip rows hidden in Excel
+	CommentPrefix string             // skip rows whose first cell starts with the prefix, e.g. "#"
+	SkipValue     string             // skip rows with the value in column SkipCol, e.g. "Subtotal"
+	SkipCol       int                // column index (zero based) checked for SkipValue
+	SkipRow       func(RowView) bool // skip rows for which the function returns true
+
+	sheetPattern *regexp.Regexp // pattern the sheet was selected with, see the sheetname option
 }
 
 // DefaultSheetOptions returns a SheetOptions instance for most common sheet structure, i.e.,
@@ -40,19 +102,91 @@ func DefaultSheetOptions() *SheetOptions {
 // in the slice of struct pointed to by a. If a is nil or not a pointer,
 // Unmarshal returns an [InvalidUnmarshalError].
 //
-// Unmarshal can only store sheet data in a struct.
-// Supported field types include: bool, float, int, string and time.Time.
+// In lenient mode (see [SheetOptions]) the rows read successfully are stored in a
+// even when an [UnmarshalErrors] is returned.
+//
+// Unmarshal stores sheet data in a struct, or in a record of type map[string]any or
+// map[string]string holding the non-empty cells of a row keyed by heading, for sheets
+// without a matching struct. Record values in a map[string]any are float64, bool,
+// time.Time for numbers formatted as dates, or string. Heading rows are not detected
+// for records.
+//
+// Supported field types include: bool, float, int, string and time.Time, and
+// pointers to these types, and slices of these types read from delimited cell
+// values. A pointer field is left nil when its cell is empty and no default value
+// is given. Types implementing [CellUnmarshaler] or
+// [encoding.TextUnmarshaler] unmarshal themselves.
 //
 // Examples of struct field tags and their meanings:
 //
 //	// Field values come from column with heading "Order Date".
 //	Date time.Time `column:"heading=Order Date"`
 //
+//	// Unmarshal fails when the sheet has no column with heading "Unit Cost".
+//	Cost float32 `column:"heading=Unit Cost,required"`
+//
+//	// Field values come from the first column found with heading "Order Date", "Date" or "Fecha".
+//	Date time.Time `column:"heading=Order Date|Date|Fecha"`
+//
 //	// Field values have all leading and trailing white space removed.
 //	Region string `column:"heading=Region,trim"`
 //
 //	// Field value defaults to "1" when cell is empty.
 //	Units int32 `column:"heading=Units,default=1"`
+//
+//	// Field values must be between 1 and 100, otherwise a [ValidationError] is returned.
+//	Units int32 `column:"heading=Units,min=1,max=100"`
+//
+//	// Field values must be one of "North", "South" or "East" and are never blank.
+//	Region string `column:"heading=Region,oneof=North|South|East,notblank"`
+//
+//	// Field values are converted by the converter named "money", see [RegisterConverter].
+//	Price int64 `column:"heading=Price,conv=money"`
+//
+//	// Field values are split on ";" and each element is trimmed, e.g. "red; green".
+//	Colors []string `column:"heading=Colors,sep=;,trim"`
+//
+//	// Field values come from column "C", or the third column, whatever its heading.
+//	Code string `column:"col=C"`
+//	Code string `column:"index=2"`
+//
+//	// Field receives the values of columns not mapped to other fields, keyed by heading.
+//	// The field type must be map[string]string or map[string]any.
+//	Extra map[string]string `column:",rest"`
+//
+//	// Field values come from columns "Jan" through "Dec", in sheet order.
+//	Monthly []float64 `column:"headings=Jan..Dec"`
+//
+//	// Field values come from columns matching the pattern, keyed by the capture
+//	// group, e.g. "1" for column "Score 1". Without a capture group, keys are headings.
+//	// The pattern is matched against the trimmed heading regardless of HeadingMatch, so
+//	// use "(?i)" to ignore case. Like other option values, it cannot contain a comma.
+//	Scores map[string]int `column:"match=^Score (\d+)$"`
+//
+//	// One struct is read per non-empty cell of columns "Jan" through "Dec", with the
+//	// heading in the variable field, see [UnmarshalLong].
+//	Month string  `column:",variable"`
+//	Value float64 `column:"headings=Jan..Dec,value"`
+//
+//	// Field value is the name of the sheet, see [UnmarshalSheets].
+//	Sheet string `column:",sheetname"`
+//
+//	// Field value is the row number as shown in Excel, e.g. 17 for row "17".
+//	// The field type must be a number, a string or an unmarshaler, or use the conv option.
+//	Row int `column:",rownum"`
+//
+//	// Field receives the A1 address of each mapped cell of the row, keyed by heading.
+//	// The field type must be map[string]string.
+//	Cells map[string]string `column:",cells"`
+//
+//	// Fields of Address come from columns "Ship City", "Ship Zip", etc.
+//	Ship Address `column:"prefix=Ship "`
+//
+// Fields of embedded structs are mapped as if they were fields of the outer struct.
+//
+// Rules spanning several fields can be checked by implementing [RowValidator] or
+// [RowUnmarshalHook] on the struct. Errors returned by these methods are wrapped
+// in a [RowError].
 func Unmarshal(sheet *xlsx3.Sheet, a any, opt *SheetOptions) error {
 	v := reflect.ValueOf(a)
 	if v.Kind() != reflect.Pointer || v.IsNil() {
@@ -62,96 +196,291 @@ func Unmarshal(sheet *xlsx3.Sheet, a any, opt *SheetOptions) error {
 		return &InvalidUnmarshalError{reflect.TypeOf(a)}
 	}
 
-	t := v.Elem().Type().Elem()
-	items, err := unmarshalStructs(t, sheet, opt)
+	s, err := appendStructs(reflect.MakeSlice(v.Elem().Type(), 0, 0), sheet, opt)
 	if err != nil {
+		var errs *UnmarshalErrors
+		if errors.As(err, &errs) {
+			v.Elem().Set(s)
+		}
 		return err
 	}
 
-	s := reflect.MakeSlice(v.Elem().Type(), 0, len(items))
-	for _, i := range items {
-		s = reflect.Append(s, reflect.ValueOf(i))
-	}
-
 	v.Elem().Set(s)
 
 	return nil
 }
 
-func unmarshalStructs(t reflect.Type, sheet *xlsx3.Sheet, opt *SheetOptions) ([]any, error) {
-	if sheet == nil {
-		return nil, nil
+// appendStructs appends the structs read from the sheet to slice s, and returns the
+// extended slice along with the first error.
+func appendStructs(s reflect.Value, sheet *xlsx3.Sheet, opt *SheetOptions) (reflect.Value, error) {
+	for item, err := range unmarshalStructs(s.Type().Elem(), sheet, opt) {
+		if err != nil {
+			return s, err
+		}
+		s = reflect.Append(s, reflect.ValueOf(item))
 	}
+	return s, nil
+}
 
-	if opt == nil {
-		opt = DefaultSheetOptions()
+// Rows returns an iterator over the rows of the sheet, yielding one struct of type T
+// per row of data. Rows are decoded as the iterator advances, so large sheets can be
+// processed without holding every struct in memory. Iteration stops after the first error.
+//
+// T must be a struct or a pointer to a struct and uses the same field tags as [Unmarshal].
+//
+//	for order, err := range xlsx2struct.Rows[*SaleOrder](sheet, opt) {
+//		if err != nil {
+//			return err
+//		}
+//		process(order)
+//	}
+func Rows[T any](sheet *xlsx3.Sheet, opt *SheetOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		for item, err := range unmarshalStructs(reflect.TypeFor[T](), sheet, opt) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(item.(T), nil) {
+				return
+			}
+		}
 	}
+}
 
-	fields, err := mapStructToSheet(t, sheet, opt.Row, opt.Col)
-	if err != nil {
-		return nil, err
-	}
+// unmarshalStructs returns an iterator over structs of type t read from the sheet rows.
+func unmarshalStructs(t reflect.Type, sheet *xlsx3.Sheet, opt *SheetOptions) iter.Seq2[any, error] {
+	return func(yield func(any, error) bool) {
+		if sheet == nil {
+			return
+		}
 
-	row := opt.DataRow
-	items := []any{}
+		if opt == nil {
+			opt = DefaultSheetOptions()
+		}
 
-	for {
-		values, ok, err := unmarshalFields(fields, sheet, row)
+		if opt.DetectRows > 0 && !opt.NoHeadings && !isRecordType(t) {
+			d, err := detectHeadings(t, sheet, opt)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			o := *opt
+			o.Row, o.Col, o.DataRow = d.Row, d.Col, d.DataRow
+			opt = &o
+
+			if opt.OnDetect != nil {
+				opt.OnDetect(*d)
+			}
+		}
+
+		fields, err := mapStructToSheet(t, sheet, opt)
 		if err != nil {
-			return nil, err
+			yield(nil, err)
+			return
 		}
 
-		if !ok {
-			break // empty row found
+		if err := resolveConverters(fields, opt); err != nil {
+			yield(nil, err)
+			return
 		}
 
-		item, err := newStruct(t, values)
+		order := sortFields(fields) // fields are read in struct order
+
+		sheetValues, err := unmarshalSheetFields(fields, sheet, opt.sheetPattern)
 		if err != nil {
-			return nil, err
+			yield(nil, err)
+			return
 		}
 
-		items = append(items, item)
-		row += 1
-	}
+		row := opt.DataRow
+		empty := 0 // number of consecutive empty rows
+		errs := &UnmarshalErrors{}
+		hooks := hasRowHooks(t)
+
+		for {
+			if rule, stop := opt.stopBefore(sheet, row); stop {
+				opt.stop(sheet, rule, row)
+				break
+			}
+
+			if opt.skipRow(sheet, fields, row) {
+				row += 1
+				continue
+			}
+
+			values, ok, err := unmarshalFields(order, fields, sheet, row)
+
+			// errors of an empty row come from validating default values, and are ignored
+			if !ok {
+				empty += 1
+				if opt.stopAtEmpty(empty) {
+					opt.stop(sheet, StopAtEmptyRows, row-empty+1)
+					break
+				}
+				row += 1
+				continue // skip empty row
+			}
+			empty = 0
+
+			if err != nil && !opt.Lenient {
+				yield(nil, err)
+				return
+			}
+
+			if err != nil {
+				errs.add(err, opt.MaxErrors)
+				if errs.exceeds(opt.MaxErrors) {
+					yield(nil, errs)
+					return
+				}
+				row += 1
+				continue // skip invalid row
+			}
+
+			maps.Copy(values, sheetValues)
+
+			rowValues, err := unmarshalRowFields(fields, row)
+			if err != nil {
+				yield(nil, &RowError{Sheet: sheet.Name, Row: row + 1, Err: err})
+				return
+			}
+			maps.Copy(values, rowValues)
+
+			for _, values := range unpivot(values) {
+				item, err := newStruct(t, values)
+				if err != nil {
+					yield(nil, &RowError{Sheet: sheet.Name, Row: row + 1, Err: err})
+					return
+				}
+
+				if hooks {
+					item, err = afterUnmarshal(item, newRowContext(sheet, fields, row))
+					if err != nil {
+						err = &RowError{Sheet: sheet.Name, Row: row + 1, Err: err}
+						if !opt.Lenient {
+							yield(nil, err)
+							return
+						}
+
+						errs.add(err, opt.MaxErrors)
+						if errs.exceeds(opt.MaxErrors) {
+							yield(nil, errs)
+							return
+						}
+						continue // skip invalid struct
+					}
+				}
+
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			row += 1
+		}
 
-	return items, nil
+		if len(errs.Errors) > 0 {
+			yield(nil, errs)
+		}
+	}
 }
 
-// unmarshalStruct unmarshals fields from the given sheet row.
-func unmarshalFields(fields map[*Field]*Column, sheet *xlsx3.Sheet, row int) (map[*Field]any, bool, error) {
+// unmarshalFields unmarshals fields from the given sheet row. Fields are read in the
+// given order, see sortFields, and all field errors in the row are returned joined together.
+func unmarshalFields(order []*Field, fields map[*Field]*Column, sheet *xlsx3.Sheet, row int) (map[*Field]any, bool, error) {
 	if sheet == nil || row < 0 || len(fields) == 0 {
 		return nil, false, nil
 	}
 
-	m := map[*Field]any{}
+	m := make(map[*Field]any, len(order))
 	allOk := false
+	errs := []error{}
+
+	for _, f := range order {
+		if f.tag.rest {
+			v, ok := unmarshalRest(f, sheet, row)
+			allOk = allOk || ok
+			m[f] = v
+			continue
+		}
 
-	for f, col := range fields {
-		var c *xlsx3.Cell
+		if f.virtual() {
+			continue // set by unpivot, or from the sheet and row
+		}
+
+		if f.group != nil {
+			unmarshal := unmarshalGroup
+			if f.tag.value {
+				unmarshal = unmarshalValues
+			}
+
+			v, ok, err := unmarshal(f, sheet, row)
+			allOk = allOk || ok
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			m[f] = v
+			continue
+		}
 
-		if col != nil {
+		c := &xlsx3.Cell{} // missing column reads as empty, see DefaultMissingColumns
+
+		heading := f.Heading()
+		if col := fields[f]; col != nil {
 			c, _ = sheet.Cell(row, col.Index) // TODO: ok to ignore error...?
+			if col.Heading != "" {
+				heading = col.Heading // heading as found in the sheet
+			}
+		}
+
+		v, ok, err := unmarshalColumn(f, c, heading)
+		if ok {
+			allOk = true
 		}
 
-		v, ok, err := unmarshalField(f, c)
 		if err != nil {
-			return nil, false, err
+			errs = append(errs, err)
+			continue
 		}
 
 		m[f] = v
+	}
 
-		if ok {
-			allOk = true
-		}
+	if len(errs) > 0 {
+		return nil, allOk, errors.Join(errs...)
 	}
 
 	return m, allOk, nil
 }
 
-func mapStructToSheet(t reflect.Type, sheet *xlsx3.Sheet, row, col int) (map[*Field]*Column, error) {
-	cols, err := extractColumns(sheet, row, col)
-	if err != nil {
-		return nil, err
+// sortFields returns the mapped fields in struct order.
+func sortFields(fields map[*Field]*Column) []*Field {
+	fs := make([]*Field, 0, len(fields))
+	for f := range fields {
+		fs = append(fs, f)
+	}
+
+	slices.SortFunc(fs, func(a, b *Field) int {
+		return slices.Compare(a.Index, b.Index)
+	})
+
+	return fs
+}
+
+func mapStructToSheet(t reflect.Type, sheet *xlsx3.Sheet, opt *SheetOptions) (map[*Field]*Column, error) {
+	var cols []*Column
+	var err error
+
+	if !opt.NoHeadings {
+		cols, err = headingColumns(sheet, opt.Row, opt.Col, opt)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	fields, err := extractFields(t)
@@ -159,44 +488,211 @@ func mapStructToSheet(t reflect.Type, sheet *xlsx3.Sheet, row, col int) (map[*Fi
 		return nil, err
 	}
 
-	return mapFields(fields, cols), nil
+	m := mapFields(fields, cols, opt.HeadingMatch)
+	if err := checkColumns(sheet, fields, cols, m, opt); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// checkColumns checks that the required fields are mapped, and when extra columns are
+// disallowed, that every column is mapped.
+func checkColumns(sheet *xlsx3.Sheet, fields []*Field, columns []*Column, m map[*Field]*Column, opt *SheetOptions) error {
+	missing := []string{}
+	mapped := map[*Column]bool{}
+
+	for _, f := range fields {
+		c := m[f]
+		if f.group != nil {
+			if len(f.group.columns) == 0 && (opt.Strict || f.tag.required) {
+				h := f.group.String()
+				if h == "" {
+					h = f.Heading()
+				}
+				missing = append(missing, h)
+			}
+			for _, gc := range f.group.columns {
+				mapped[gc] = true
+			}
+			continue
+		}
+		if c == nil && !f.tag.rest && !f.virtual() && (opt.Strict || f.tag.required || !opt.DefaultMissingColumns) {
+			missing = append(missing, f.Heading())
+		}
+		mapped[c] = true
+		for _, rc := range f.restColumns {
+			mapped[rc] = true
+		}
+	}
+
+	if len(missing) > 0 {
+		return &MissingColumnsError{Sheet: sheet.Name, Headings: missing}
+	}
+
+	if !opt.DisallowExtraColumns {
+		return nil
+	}
+
+	extra := []string{}
+	for _, c := range columns {
+		if !mapped[c] {
+			extra = append(extra, c.Heading)
+		}
+	}
+
+	if len(extra) > 0 {
+		return &ExtraColumnsError{Sheet: sheet.Name, Headings: extra}
+	}
+
+	return nil
 }
 
-// mapFields maps fields of struct to sheet columns.
-func mapFields(fields []*Field, columns []*Column) map[*Field]*Column {
+// mapFields maps fields of struct to sheet columns. A field is mapped to the column
+// set by its col or index option, or else to the column matching its first heading
+// found in the sheet. Fields with the headings or match option are assigned all columns
+// of their group, and columns not mapped to any field are assigned to fields with the
+// rest option and to a value field without headings or pattern.
+func mapFields(fields []*Field, columns []*Column, match HeadingMatch) map[*Field]*Column {
 	cs := map[string]*Column{}
+	is := map[int]*Column{}
 	for _, c := range columns {
-		cs[c.Heading] = c
+		cs[match.key(c.Heading)] = c
+		is[c.Index] = c
 	}
 
 	fs := map[*Field]*Column{}
+	mapped := map[*Column]bool{}
+	for _, f := range fields {
+		fs[f] = nil
+		if f.tag.rest || f.virtual() {
+			continue
+		}
+		if f.group != nil {
+			if f.group.unmapped() {
+				continue
+			}
+			f.group.columns = f.group.match(columns, match)
+			for _, c := range f.group.columns {
+				mapped[c] = true
+			}
+			continue
+		}
+		if i, ok := f.fixedIndex(); ok {
+			c := is[i]
+			if c == nil {
+				c = &Column{Heading: f.Heading(), Index: i}
+			}
+			fs[f] = c
+			mapped[c] = true
+			continue
+		}
+		for _, h := range f.Headings() {
+			if c, ok := cs[match.key(h)]; ok {
+				fs[f] = c
+				mapped[c] = true
+				break
+			}
+		}
+	}
+
 	for _, f := range fields {
-		h := f.Heading()
-		fs[f] = cs[h]
+		if f.group != nil && f.group.unmapped() {
+			f.group.columns = nil
+			for _, c := range columns {
+				if !mapped[c] {
+					f.group.columns = append(f.group.columns, c)
+				}
+			}
+		}
+		if !f.tag.rest {
+			continue
+		}
+		f.restColumns = nil
+		for _, c := range columns {
+			if !mapped[c] {
+				f.restColumns = append(f.restColumns, c)
+			}
+		}
 	}
 
 	return fs
 }
 
-// t must be a struct type or pointer to a struct type.
+// t must be a struct type or pointer to a struct type, or a record type.
 func extractFields(t reflect.Type) ([]*Field, error) {
-	var s reflect.Type
+	if isRecordType(t) {
+		return recordFields(t), nil
+	}
 
-	if t.Kind() == reflect.Struct {
-		s = t
-	} else if t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct {
-		s = t.Elem()
-	} else {
+	s, _ := getStructType(t)
+	if s == nil {
 		return nil, &InvalidUnmarshalError{Type: t}
 	}
 
+	fs, err := structFields(s, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := linkUnpivotFields(fs); err != nil {
+		return nil, err
+	}
+
+	return fs, nil
+}
+
+// structFields returns the fields of struct type s. Fields of embedded and nested
+// structs are flattened, with headings of nested fields prefixed by the parent's prefix option.
+func structFields(s reflect.Type, index []int, prefix string) ([]*Field, error) {
 	fs := make([]*Field, 0)
 
 	for i := 0; i < s.NumField(); i++ {
-		f := Field{StructField: s.Field(i)}
+		f := Field{StructField: s.Field(i), prefix: prefix}
+		f.Index = append(slices.Clone(index), i)
 		if c := f.Tag.Get(ColumnTag); c != "" {
 			f.tag = parseColumnTag(c)
 		}
+
+		if f.nested() {
+			nfs, err := structFields(f.Type, f.Index, prefix+f.tag.prefix)
+			if err != nil {
+				return nil, err
+			}
+			fs = append(fs, nfs...)
+			continue
+		}
+
+		if f.tag.rest && !isRestType(f.Type) {
+			return nil, &InvalidTagError{Field: &f, Option: RestOption}
+		}
+
+		if f.tag.cells && f.Type != restStringType {
+			return nil, &InvalidTagError{Field: &f, Option: CellsOption}
+		}
+
+		if f.tag.rowNum && !isRowNumType(f.Type, f.tag.converter) {
+			return nil, &InvalidTagError{Field: &f, Option: RowNumOption}
+		}
+
+		if err := f.checkFixedIndex(); err != nil {
+			return nil, err
+		}
+
+		g, err := newColumnGroup(&f)
+		if err != nil {
+			return nil, err
+		}
+		if g != nil {
+			f.group = g
+			fs = append(fs, &f)
+			continue
+		}
+
+		if err := compileRules(&f); err != nil {
+			return nil, err
+		}
+
 		fs = append(fs, &f)
 	}
 
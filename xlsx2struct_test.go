@@ -2,6 +2,7 @@ package xlsx2struct
 
 import (
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -40,20 +41,251 @@ func TestUnmarshal(t *testing.T) {
 	require.Equal(t, "Pencil", a[2].Item)
 }
 
+func TestRows(t *testing.T) {
+	sheet, opt := openSalesOrdersSheet(t)
+
+	n := 0
+	for o, err := range Rows[*SaleOrder](sheet, opt) {
+		require.NoError(t, err)
+		require.NotNil(t, o)
+		require.False(t, o.Date.IsZero())
+		n++
+	}
+	require.Equal(t, 20, n)
+
+	// early break
+	n = 0
+	for range Rows[SaleOrder](sheet, opt) {
+		n++
+		if n == 5 {
+			break
+		}
+	}
+	require.Equal(t, 5, n)
+
+	// invalid type
+	for _, err := range Rows[string](sheet, opt) {
+		require.EqualError(t, err, "xlsx2struct: invalid unmarshal(non-pointer string)")
+	}
+}
+
+func TestUnmarshalLenient(t *testing.T) {
+	type Struct1 struct {
+		Name  string  `column:"heading=Name"`
+		Units int     `column:"heading=Units"`
+		Cost  float64 `column:"heading=Cost"`
+	}
+
+	sheet := sheetOf(t,
+		[]string{"Name", "Units", "Cost"},
+		[]string{"a", "1", "1.5"},
+		[]string{"b", "ten", "x"},
+		[]string{"c", "3", "3.5"},
+		[]string{"d", "four", "4.5"},
+	)
+
+	// strict
+	a := []Struct1{}
+	err := Unmarshal(sheet, &a, nil)
+	require.Error(t, err)
+	require.Empty(t, a)
+
+	// lenient
+	opt := DefaultSheetOptions()
+	opt.Lenient = true
+	err = Unmarshal(sheet, &a, opt)
+	require.Error(t, err)
+
+	var errs *UnmarshalErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs.Errors, 3)
+	require.Equal(t, []Struct1{{"a", 1, 1.5}, {"c", 3, 3.5}}, a)
+
+	var ferr *UnmarshalFieldError
+	require.ErrorAs(t, err, &ferr)
+	require.Equal(t, "Units", ferr.Heading)
+	require.Equal(t, "B3", ferr.Address())
+	require.Equal(t, "ten", ferr.Value)
+	require.ErrorIs(t, err, strconv.ErrSyntax)
+	require.Equal(t, "xlsx2struct: sheet 'Sheet1', cell B3 (column 'Units'): cannot unmarshal 'ten' into field 'Units' (type: int, column: 'Units'): invalid syntax", ferr.Error())
+
+	// maximum errors
+	opt.MaxErrors = 2
+	err = Unmarshal(sheet, &a, opt)
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs.Errors, 2)
+	require.Equal(t, []Struct1{{"a", 1, 1.5}}, a)
+
+	// maximum errors within a row
+	opt.MaxErrors = 1
+	err = Unmarshal(sheet, &a, opt)
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs.Errors, 1)
+	require.Equal(t, "B3", errs.Errors[0].(*UnmarshalFieldError).Address())
+}
+
+func TestUnmarshalMissingColumns(t *testing.T) {
+	type Struct1 struct {
+		Name  string  `column:"heading=Name,required"`
+		Units int     `column:"heading=Units,default=1"`
+		Cost  float64 `column:"heading=Unit Cost"`
+	}
+
+	sheet := sheetOf(t,
+		[]string{"Name", "Cost", "Notes"},
+		[]string{"a", "1.5", "n"},
+	)
+
+	// missing columns fail upfront
+	a := []Struct1{}
+	err := Unmarshal(sheet, &a, nil)
+	var merr *MissingColumnsError
+	require.ErrorAs(t, err, &merr)
+	require.Equal(t, []string{"Units", "Unit Cost"}, merr.Headings)
+
+	// missing optional columns get default values
+	opt := DefaultSheetOptions()
+	opt.DefaultMissingColumns = true
+	err = Unmarshal(sheet, &a, opt)
+	require.NoError(t, err)
+	require.Equal(t, []Struct1{{Name: "a", Units: 1}}, a)
+
+	// strict
+	opt.Strict = true
+	err = Unmarshal(sheet, &a, opt)
+	require.ErrorAs(t, err, &merr)
+	require.Equal(t, []string{"Units", "Unit Cost"}, merr.Headings)
+	require.EqualError(t, err, "xlsx2struct: sheet 'Sheet1' is missing column(s) 'Units', 'Unit Cost'")
+
+	// required
+	opt.Strict = false
+	sheet = sheetOf(t, []string{"Units", "Unit Cost"}, []string{"1", "1.5"})
+	err = Unmarshal(sheet, &a, opt)
+	require.ErrorAs(t, err, &merr)
+	require.Equal(t, []string{"Name"}, merr.Headings)
+
+	// extra columns
+	sheet = sheetOf(t, []string{"Name", "Units", "Unit Cost", "Notes", "Tax"}, []string{"a", "1", "1.5", "n", "0"})
+	opt.DisallowExtraColumns = true
+	err = Unmarshal(sheet, &a, opt)
+	var xerr *ExtraColumnsError
+	require.ErrorAs(t, err, &xerr)
+	require.Equal(t, []string{"Notes", "Tax"}, xerr.Headings)
+}
+
+func TestUnmarshalFixedColumns(t *testing.T) {
+	type Struct1 struct {
+		Code  string `column:"col=C"`
+		Name  string `column:"index=0"`
+		Units int    `column:"heading=Units"`
+	}
+
+	// garbage headings
+	sheet := sheetOf(t,
+		[]string{"x", "Units", "y"},
+		[]string{"Pencil", "10", "P1"},
+	)
+
+	a := []Struct1{}
+	err := Unmarshal(sheet, &a, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Struct1{{Code: "P1", Name: "Pencil", Units: 10}}, a)
+
+	// no heading row
+	sheet = sheetOf(t,
+		[]string{"Pencil", "10", "P1"},
+		[]string{"Pen", "20", "P2"},
+	)
+
+	opt := DefaultSheetOptions()
+	opt.NoHeadings = true
+	opt.DefaultMissingColumns = true // Units has no fixed column
+	opt.DataRow = 0
+	err = Unmarshal(sheet, &a, opt)
+	require.NoError(t, err)
+	require.Equal(t, []Struct1{{Code: "P1", Name: "Pencil"}, {Code: "P2", Name: "Pen"}}, a)
+
+	// round trip
+	out := newSheet(t)
+	err = Marshal(out, a, opt)
+	require.NoError(t, err)
+	c, _ := out.Cell(0, 2)
+	require.Equal(t, "P1", c.Value)
+
+	b := []Struct1{}
+	err = Unmarshal(out, &b, opt)
+	require.NoError(t, err)
+	require.Equal(t, a, b)
+
+	// invalid options
+	_, err = fields(struct {
+		A string `column:"col=1"`
+	}{})
+	require.EqualError(t, err, `xlsx2struct: invalid option "col=1" for field 'A' (type: string, column: 'A')`)
+	_, err = fields(struct {
+		A string `column:"index=-1"`
+	}{})
+	require.EqualError(t, err, `xlsx2struct: invalid option "index=-1" for field 'A' (type: string, column: 'A')`)
+}
+
+type Address struct {
+	City string
+	Zip  string `column:"heading=Zip Code"`
+}
+
+type Audit struct {
+	Created time.Time `column:"heading=Created"`
+}
+
+type Shipment struct {
+	Audit
+	ID   int     `column:"heading=ID"`
+	Ship Address `column:"prefix=Ship "`
+	Bill Address `column:"prefix=Bill "`
+}
+
+func TestUnmarshalNested(t *testing.T) {
+	sheet := sheetOf(t,
+		[]string{"ID", "Created", "Ship City", "Ship Zip Code", "Bill City", "Bill Zip Code"},
+		[]string{"1", "2025-01-02", "Leeds", "LS1", "York", "YO1"},
+	)
+
+	a := []Shipment{}
+	err := Unmarshal(sheet, &a, nil)
+	require.NoError(t, err)
+	require.Len(t, a, 1)
+	require.Equal(t, 1, a[0].ID)
+	require.Equal(t, "2025-01-02", a[0].Created.Format(time.DateOnly))
+	require.Equal(t, Address{City: "Leeds", Zip: "LS1"}, a[0].Ship)
+	require.Equal(t, Address{City: "York", Zip: "YO1"}, a[0].Bill)
+
+	// round trip
+	out := newSheet(t)
+	err = Marshal(out, a, nil)
+	require.NoError(t, err)
+	c, _ := out.Cell(0, 3)
+	require.Equal(t, "Ship Zip Code", c.Value)
+
+	b := []Shipment{}
+	err = Unmarshal(out, &b, nil)
+	require.NoError(t, err)
+	require.Equal(t, a, b)
+}
+
 func TestUnmarshalFields(t *testing.T) {
 	sheet, _ := openSalesOrdersSheet(t)
 
 	type Struct1 = SaleOrder
-	fields, err := mapStructToSheet(reflect.TypeOf(Struct1{}), sheet, 0, 0)
+	fields, err := mapStructToSheet(reflect.TypeOf(Struct1{}), sheet, DefaultSheetOptions())
 	require.NoError(t, err)
 
 	// empty row
-	_, ok, err := unmarshalFields(fields, sheet, 25)
+	_, ok, err := unmarshalFields(sortFields(fields), fields, sheet, 25)
 	require.NoError(t, err)
 	require.False(t, ok)
 
 	// data row
-	a, ok, err := unmarshalFields(fields, sheet, 1)
+	a, ok, err := unmarshalFields(sortFields(fields), fields, sheet, 1)
 	require.NoError(t, err)
 	require.True(t, ok)
 	require.NotNil(t, a)
@@ -61,7 +293,36 @@ func TestUnmarshalFields(t *testing.T) {
 }
 
 func TestMapFields(t *testing.T) {
-	// TODO: complete test case
+	type Struct1 struct {
+		Date   time.Time `column:"heading=Order Date|Date|Fecha"`
+		Region string    `column:"heading=Region"`
+		Units  int       `column:"heading=Units"`
+	}
+
+	fields, err := extractFields(reflect.TypeOf(Struct1{}))
+	require.NoError(t, err)
+
+	cols := []*Column{{Heading: "Fecha", Index: 0}, {Heading: " region\n", Index: 1}, {Heading: "Order\nDate ", Index: 2}, {Heading: "UNITS", Index: 3}}
+
+	// exact, with aliases
+	m := mapFields(fields, cols, MatchExact)
+	require.Equal(t, 0, m[fields[0]].Index)
+	require.Nil(t, m[fields[1]])
+	require.Nil(t, m[fields[2]])
+
+	// normalized white space, first heading is preferred
+	m = mapFields(fields, cols, MatchNormalizeSpace)
+	require.Equal(t, 2, m[fields[0]].Index)
+	require.Nil(t, m[fields[1]])
+
+	// case-fold
+	m = mapFields(fields, cols, MatchFoldCase)
+	require.Nil(t, m[fields[1]])
+	require.Equal(t, 3, m[fields[2]].Index)
+
+	m = mapFields(fields, cols, MatchFoldCase|MatchNormalizeSpace)
+	require.Equal(t, 1, m[fields[1]].Index)
+	require.Equal(t, 3, m[fields[2]].Index)
 }
 
 func TestExtractColumns(t *testing.T) {
@@ -75,6 +336,21 @@ func TestExtractColumns(t *testing.T) {
 	require.Equal(t, 6, cols[6].Index)
 }
 
+func sheetOf(t *testing.T, rows ...[]string) *xlsx.Sheet {
+	s := newSheet(t)
+	addRows(s, rows...)
+	return s
+}
+
+func addRows(sheet *xlsx.Sheet, rows ...[]string) {
+	for _, r := range rows {
+		row := sheet.AddRow()
+		for _, v := range r {
+			row.AddCell().SetString(v)
+		}
+	}
+}
+
 func openSalesOrdersSheet(t *testing.T) (*xlsx.Sheet, *SheetOptions) {
 	sheet := openSheet(t, "testdata/salesorders.xlsx", "Sales Orders")
 	opt := DefaultSheetOptions()